@@ -5,6 +5,7 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	_ "time/tzdata" // User time zones must resolve even on images without zoneinfo
 
 	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/config"
 	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/database"
@@ -39,18 +40,62 @@ func main() {
 	}
 
 	// Services
-	authService := services.NewAuthService(database.DB, cfg)
-	subscriptionService := services.NewSubscriptionService(database.DB)
+	streakService := services.NewStreakService(database.DB)
+	subscriptionService := services.NewSubscriptionService(database.DB, streakService, cfg.StreakRepairProductID)
 	moderationService := services.NewModerationService(database.DB)
-	vibeService := services.NewVibeService(database.DB, cfg.OpenAIKey)
+	friendService := services.NewFriendService(database.DB, moderationService)
+	referralService := services.NewReferralService(database.DB, streakService, friendService)
+	authService := services.NewAuthService(database.DB, cfg, referralService)
+	quizService := services.NewQuizService(database.DB)
+	achievementService := services.NewAchievementService(database.DB)
+	forecastService := services.NewForecastService(database.DB)
+	settingsService := services.NewSettingsService(database.DB)
+	anomalyService := services.NewAnomalyService(database.DB, settingsService)
+	termService := services.NewTermService(database.DB)
+	discoverService := services.NewDiscoverService(database.DB, moderationService, settingsService)
+	vibeService := services.NewVibeService(database.DB, cfg.OpenAIKey, quizService, streakService, achievementService, forecastService, anomalyService, termService, settingsService, discoverService, referralService)
+	cardService := services.NewCardService()
+	shareService := services.NewShareService(database.DB)
+	summaryService := services.NewSummaryService(database.DB)
+	patternService := services.NewPatternService(database.DB, settingsService)
+	feedService := services.NewFeedService(database.DB)
+	interactionService := services.NewInteractionService(database.DB, moderationService)
+	circleService := services.NewCircleService(database.DB, moderationService)
+	leaderboardService := services.NewLeaderboardService(database.DB, circleService, settingsService, streakService)
+	profileService := services.NewProfileService(database.DB, moderationService)
+	compatibilityService := services.NewCompatibilityService(database.DB, friendService, moderationService)
+	globalStatsService := services.NewGlobalStatsService(database.DB, settingsService, cfg.GlobalStatsNoise)
+
+	if err := quizService.SeedDefaults(); err != nil {
+		log.Printf("Quiz seed failed: %v", err)
+	}
+	if err := achievementService.SeedDefaults(); err != nil {
+		log.Printf("Achievement seed failed: %v", err)
+	}
 
 	// Handlers
 	authHandler := handlers.NewAuthHandler(authService)
 	healthHandler := handlers.NewHealthHandler()
 	webhookHandler := handlers.NewWebhookHandler(subscriptionService, cfg)
 	moderationHandler := handlers.NewModerationHandler(moderationService)
-	vibeHandler := handlers.NewVibeHandler(vibeService)
+	vibeHandler := handlers.NewVibeHandler(vibeService, cardService)
 	legalHandler := handlers.NewLegalHandler()
+	quizHandler := handlers.NewQuizHandler(quizService)
+	shareHandler := handlers.NewShareHandler(shareService, cardService, cfg)
+	streakHandler := handlers.NewStreakHandler(streakService)
+	achievementHandler := handlers.NewAchievementHandler(achievementService)
+	summaryHandler := handlers.NewSummaryHandler(summaryService, cardService)
+	settingsHandler := handlers.NewSettingsHandler(settingsService)
+	insightHandler := handlers.NewInsightHandler(patternService, forecastService, anomalyService, termService, globalStatsService)
+	friendHandler := handlers.NewFriendHandler(friendService, cfg)
+	feedHandler := handlers.NewFeedHandler(feedService)
+	compatibilityHandler := handlers.NewCompatibilityHandler(compatibilityService, cardService)
+	interactionHandler := handlers.NewInteractionHandler(interactionService)
+	circleHandler := handlers.NewCircleHandler(circleService, cfg)
+	profileHandler := handlers.NewProfileHandler(profileService)
+	discoverHandler := handlers.NewDiscoverHandler(discoverService)
+	referralHandler := handlers.NewReferralHandler(referralService, cfg)
+	leaderboardHandler := handlers.NewLeaderboardHandler(leaderboardService)
 
 	// Fiber app
 	app := fiber.New(fiber.Config{
@@ -75,7 +120,13 @@ func main() {
 	app.Use("/api/auth", authLimiter)
 
 	// Routes
-	routes.Setup(app, cfg, database.DB, authHandler, healthHandler, webhookHandler, moderationHandler, vibeHandler, legalHandler)
+	routes.Setup(app, cfg, database.DB, authHandler, healthHandler, webhookHandler, moderationHandler, vibeHandler, legalHandler, quizHandler, shareHandler, streakHandler, achievementHandler, summaryHandler, settingsHandler, insightHandler, friendHandler, feedHandler, compatibilityHandler, interactionHandler, circleHandler, profileHandler, discoverHandler, referralHandler, leaderboardHandler)
+
+	// Background jobs
+	stopJobs := make(chan struct{})
+	go summaryService.StartScheduler(time.Hour, stopJobs)          // Persist Vibe Wrapped once periods close
+	go globalStatsService.StartScheduler(15*time.Minute, stopJobs) // Anonymized daily aggregates
+	go leaderboardService.StartScheduler(time.Hour, stopJobs)      // Freeze weekly leaderboard winners
 
 	// Graceful shutdown
 	quit := make(chan os.Signal, 1)
@@ -91,6 +142,7 @@ func main() {
 
 	<-quit
 	log.Println("Shutting down server...")
+	close(stopJobs)
 	if err := app.Shutdown(); err != nil {
 		log.Fatalf("Server shutdown error: %v", err)
 	}
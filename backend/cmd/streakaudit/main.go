@@ -0,0 +1,74 @@
+// Command streakaudit recomputes VibeStreak rows from vibe_checks history and
+// reports (or, with -fix, repairs) any that have drifted.
+//
+//	go run ./cmd/streakaudit -user <uuid>
+//	go run ./cmd/streakaudit -users <uuid>,<uuid> -fix
+//	go run ./cmd/streakaudit -all -fix
+package main
+
+import (
+	"flag"
+	"log"
+	"strings"
+
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/config"
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/database"
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/services"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func main() {
+	user := flag.String("user", "", "audit a single user ID")
+	users := flag.String("users", "", "audit a comma-separated batch of user IDs")
+	all := flag.Bool("all", false, "audit every user")
+	fix := flag.Bool("fix", false, "overwrite drifted streaks with recomputed values")
+	flag.Parse()
+
+	var userIDs []uuid.UUID
+	for _, raw := range strings.Split(*user+","+*users, ",") {
+		if raw = strings.TrimSpace(raw); raw == "" {
+			continue
+		}
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			log.Fatalf("Invalid user ID %q: %v", raw, err)
+		}
+		userIDs = append(userIDs, id)
+	}
+	if len(userIDs) == 0 && !*all {
+		log.Fatal("Specify -user, -users or -all")
+	}
+	if len(userIDs) > 0 && *all {
+		log.Fatal("-all cannot be combined with -user or -users")
+	}
+
+	cfg := config.Load()
+	if cfg.DBPassword == "" {
+		log.Fatal("DB_PASSWORD environment variable is required")
+	}
+	if err := database.Connect(cfg); err != nil {
+		log.Fatalf("Database connection failed: %v", err)
+	}
+
+	// Per-query SQL logging would bury the progress output
+	db := database.DB.Session(&gorm.Session{Logger: logger.Default.LogMode(logger.Warn)})
+
+	streakService := services.NewStreakService(db)
+	report, err := streakService.AuditStreaks(services.StreakAuditOptions{
+		UserIDs: userIDs,
+		Fix:     *fix,
+		Progress: func(done, total int) {
+			log.Printf("Audited %d/%d users", done, total)
+		},
+	})
+	if err != nil {
+		log.Fatalf("Streak audit failed: %v", err)
+	}
+
+	for _, m := range report.Mismatches {
+		log.Printf("%s stored=%+v expected=%+v fixed=%t", m.UserID, m.Stored, m.Expected, m.Fixed)
+	}
+	log.Printf("Done: %d audited, %d mismatched, %d fixed", report.Audited, len(report.Mismatches), report.Fixed)
+}
@@ -2,6 +2,7 @@ package config
 
 import (
 	"os"
+	"strconv"
 	"time"
 )
 
@@ -18,6 +19,7 @@ type Config struct {
 	JWTRefreshExpiry time.Duration
 
 	RevenueCatWebhookAuth string
+	StreakRepairProductID string
 
 	OpenAIKey string
 
@@ -25,6 +27,10 @@ type Config struct {
 
 	Port        string
 	CORSOrigins string
+
+	PublicBaseURL string // Absolute origin for share links and Open Graph tags
+
+	GlobalStatsNoise float64 // Laplace noise epsilon for global comparisons; 0 disables noise
 }
 
 func Load() *Config {
@@ -41,6 +47,7 @@ func Load() *Config {
 		JWTRefreshExpiry: parseDuration(getEnv("JWT_REFRESH_EXPIRY", "168h")),
 
 		RevenueCatWebhookAuth: getEnv("REVENUECAT_WEBHOOK_AUTH", ""),
+		StreakRepairProductID: getEnv("STREAK_REPAIR_PRODUCT_ID", "vibecheck_streak_repair"),
 
 		OpenAIKey: getEnv("OPENAI_API_KEY", ""),
 
@@ -48,6 +55,10 @@ func Load() *Config {
 
 		Port:        getEnv("PORT", "8080"),
 		CORSOrigins: getEnv("CORS_ORIGINS", "*"),
+
+		PublicBaseURL: getEnv("PUBLIC_BASE_URL", ""),
+
+		GlobalStatsNoise: parseFloat(getEnv("GLOBAL_STATS_NOISE_EPSILON", "0")),
 	}
 }
 
@@ -75,3 +86,11 @@ func parseDuration(s string) time.Duration {
 	}
 	return d
 }
+
+func parseFloat(s string) float64 {
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || f < 0 {
+		return 0
+	}
+	return f
+}
@@ -16,7 +16,8 @@ var DB *gorm.DB
 func Connect(cfg *config.Config) error {
 	var err error
 	DB, err = gorm.Open(postgres.Open(cfg.DSN()), &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Info),
+		Logger:         logger.Default.LogMode(logger.Info),
+		TranslateError: true, // Surface unique violations as gorm.ErrDuplicatedKey
 	})
 	if err != nil {
 		return fmt.Errorf("failed to connect to database: %w", err)
@@ -35,6 +36,14 @@ func Connect(cfg *config.Config) error {
 }
 
 func Migrate() error {
+	if err := dedupeVibeChecks(); err != nil {
+		return fmt.Errorf("failed to dedupe vibe checks: %w", err)
+	}
+
+	// Checks from before per-check visibility take their owner's mood text sharing setting
+	backfillMoodText := DB.Migrator().HasTable(&models.VibeCheck{}) &&
+		!DB.Migrator().HasColumn(&models.VibeCheck{}, "HideMoodText")
+
 	err := DB.AutoMigrate(
 		&models.User{},
 		&models.RefreshToken{},
@@ -43,15 +52,70 @@ func Migrate() error {
 		&models.Block{},
 		&models.VibeCheck{},
 		&models.VibeStreak{},
+		&models.StreakEvent{},
+		&models.QuizSet{},
+		&models.QuizQuestion{},
+		&models.QuizOption{},
+		&models.QuizAnswer{},
+		&models.ShareLink{},
+		&models.Achievement{},
+		&models.UserAchievement{},
+		&models.VibeSummary{},
+		&models.UserSettings{},
+		&models.VibeForecast{},
+		&models.VibeBaseline{},
+		&models.Insight{},
+		&models.MoodTerm{},
+		&models.GlobalVibeStat{},
+		&models.FriendRequest{},
+		&models.Friendship{},
+		&models.InviteCode{},
+		&models.Reaction{},
+		&models.Comment{},
+		&models.Circle{},
+		&models.CircleMember{},
+		&models.Profile{},
+		&models.DiscoverEntry{},
+		&models.DiscoverReaction{},
+		&models.Referral{},
+		&models.LeaderboardResult{},
 	)
 	if err != nil {
 		return fmt.Errorf("failed to run migrations: %w", err)
 	}
 
+	if backfillMoodText {
+		if err := DB.Exec(`
+			UPDATE vibe_checks c SET hide_mood_text = TRUE
+			WHERE NOT EXISTS (
+				SELECT 1 FROM user_settings st WHERE st.user_id = c.user_id AND st.share_mood_text
+			)`).Error; err != nil {
+			return fmt.Errorf("failed to backfill hide_mood_text: %w", err)
+		}
+	}
+
 	log.Println("Database migrations completed")
 	return nil
 }
 
+// dedupeVibeChecks soft-deletes duplicate same-day checks left over from before
+// the (user_id, check_date) unique index existed, keeping the earliest one.
+func dedupeVibeChecks() error {
+	if !DB.Migrator().HasTable(&models.VibeCheck{}) {
+		return nil
+	}
+	return DB.Exec(`
+		UPDATE vibe_checks SET deleted_at = NOW()
+		WHERE id IN (
+			SELECT id FROM (
+				SELECT id, ROW_NUMBER() OVER (PARTITION BY user_id, check_date ORDER BY created_at, id) AS rn
+				FROM vibe_checks
+				WHERE user_id IS NOT NULL AND deleted_at IS NULL
+			) ranked
+			WHERE rn > 1
+		)`).Error
+}
+
 func Ping() error {
 	sqlDB, err := DB.DB()
 	if err != nil {
@@ -0,0 +1,40 @@
+package dto
+
+import "time"
+
+// AchievementResponse is a badge with the user's unlock state and progress
+type AchievementResponse struct {
+	Key         string     `json:"key"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Icon        string     `json:"icon"`
+	Unlocked    bool       `json:"unlocked"`
+	UnlockedAt  *time.Time `json:"unlocked_at,omitempty"`
+	Progress    int        `json:"progress"` // Capped at Threshold
+	Threshold   int        `json:"threshold"`
+}
+
+// --- Admin achievement DTOs ---
+
+type CreateAchievementRequest struct {
+	Key         string             `json:"key"`
+	Name        string             `json:"name"`
+	Description string             `json:"description"`
+	Icon        string             `json:"icon"`
+	Metric      string             `json:"metric"`
+	Params      map[string]float64 `json:"params"`
+	Threshold   int                `json:"threshold"`
+	SortOrder   int                `json:"sort_order"`
+	Active      bool               `json:"active"`
+}
+
+// UpdateAchievementRequest changes only the fields that are set
+type UpdateAchievementRequest struct {
+	Name        *string            `json:"name"`
+	Description *string            `json:"description"`
+	Icon        *string            `json:"icon"`
+	Params      map[string]float64 `json:"params"`
+	Threshold   *int               `json:"threshold"`
+	SortOrder   *int               `json:"sort_order"`
+	Active      *bool              `json:"active"`
+}
@@ -3,8 +3,10 @@ package dto
 import "github.com/google/uuid"
 
 type RegisterRequest struct {
-	Email    string `json:"email"`
-	Password string `json:"password"`
+	Email      string `json:"email"`
+	Password   string `json:"password"`
+	InviteCode string `json:"invite_code,omitempty"` // From an invite link; unknown codes are ignored
+	DeviceID   string `json:"device_id,omitempty"`   // Stable per-install ID, used to catch referral abuse
 }
 
 type LoginRequest struct {
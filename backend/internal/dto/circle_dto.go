@@ -0,0 +1,45 @@
+package dto
+
+import "github.com/google/uuid"
+
+type CreateCircleRequest struct {
+	Name  string `json:"name"`
+	Emoji string `json:"emoji,omitempty"`
+}
+
+type JoinCircleRequest struct {
+	Token string `json:"token"`
+}
+
+type CircleMemberResponse struct {
+	UserID   uuid.UUID       `json:"user_id"`
+	Profile  *ProfileSummary `json:"profile,omitempty"`
+	Role     string          `json:"role"`
+	JoinedAt string          `json:"joined_at"`
+}
+
+type CircleResponse struct {
+	ID          uuid.UUID              `json:"id"`
+	Name        string                 `json:"name"`
+	Emoji       string                 `json:"emoji,omitempty"`
+	OwnerID     uuid.UUID              `json:"owner_id"`
+	MaxMembers  int                    `json:"max_members"`
+	MemberCount int                    `json:"member_count"`
+	Members     []CircleMemberResponse `json:"members,omitempty"`
+	CreatedAt   string                 `json:"created_at"`
+}
+
+// CircleInviteResponse is only shown to the circle owner
+type CircleInviteResponse struct {
+	Token     string `json:"token"`
+	InviteURL string `json:"invite_url"`
+}
+
+// CircleTodayResponse is the circle's mood board for today
+type CircleTodayResponse struct {
+	Date       string         `json:"date"`
+	AvgScore   *float64       `json:"avg_score"` // Null until someone checks in
+	Aesthetics map[string]int `json:"aesthetics"`
+	CheckedIn  []FeedItem     `json:"checked_in"`
+	NotYet     []uuid.UUID    `json:"not_yet"`
+}
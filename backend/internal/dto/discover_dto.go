@@ -0,0 +1,21 @@
+package dto
+
+import "github.com/google/uuid"
+
+// DiscoverEntry is an anonymized check-in in the discover stream
+type DiscoverEntry struct {
+	ID           uuid.UUID      `json:"id"` // Use as content_id with content_type "discover" to report it
+	Aesthetic    string         `json:"aesthetic"`
+	Emoji        string         `json:"emoji"`
+	ColorPrimary string         `json:"color_primary"`
+	VibeScore    int            `json:"vibe_score"`
+	MoodText     *string        `json:"mood_text,omitempty"`
+	Date         string         `json:"date"`
+	Reactions    map[string]int `json:"reactions"`
+	MyReaction   string         `json:"my_reaction,omitempty"`
+}
+
+type DiscoverResponse struct {
+	Data       []DiscoverEntry `json:"data"`
+	NextCursor string          `json:"next_cursor,omitempty"`
+}
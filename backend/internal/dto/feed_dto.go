@@ -0,0 +1,30 @@
+package dto
+
+import "github.com/google/uuid"
+
+// FeedItem is a friend's check-in as shown in the feed
+type FeedItem struct {
+	CheckID      uuid.UUID       `json:"check_id"`
+	UserID       uuid.UUID       `json:"user_id"`
+	Profile      *ProfileSummary `json:"profile,omitempty"`
+	Aesthetic    string          `json:"aesthetic"`
+	Emoji        string          `json:"emoji"`
+	ColorPrimary string          `json:"color_primary"`
+	VibeScore    int             `json:"vibe_score"`
+	MoodText     *string         `json:"mood_text,omitempty"` // Only when the friend shares mood text
+	CheckDate    string          `json:"check_date"`
+	CreatedAt    string          `json:"created_at"`
+}
+
+type FeedResponse struct {
+	Data       []FeedItem `json:"data"`
+	NextCursor string     `json:"next_cursor,omitempty"` // Pass as ?cursor= for the next page; empty at the end
+}
+
+// FeedTodayResponse splits friends by whether they've checked in today
+type FeedTodayResponse struct {
+	Date       string      `json:"date"`
+	CheckedIn  []FeedItem  `json:"checked_in"`
+	NotYet     []uuid.UUID `json:"not_yet"` // Friends without a check-in today
+	TotalCount int         `json:"total_friends"`
+}
@@ -0,0 +1,9 @@
+package dto
+
+// ForecastAccuracy summarizes how resolved forecasts compared with actual check-ins
+type ForecastAccuracy struct {
+	Resolved         int     `json:"resolved"`
+	MeanAbsError     float64 `json:"mean_abs_error"`
+	WithinBandRate   float64 `json:"within_band_rate"`   // Share of actual scores inside the band (target 0.8)
+	AestheticHitRate float64 `json:"aesthetic_hit_rate"` // Share where the predicted aesthetic came true
+}
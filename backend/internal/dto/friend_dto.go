@@ -0,0 +1,51 @@
+package dto
+
+import "github.com/google/uuid"
+
+// SendFriendRequest targets a user by ID, username or invite code
+type SendFriendRequest struct {
+	UserID   *uuid.UUID `json:"user_id,omitempty"`
+	Username string     `json:"username,omitempty"`
+	Code     string     `json:"code,omitempty"`
+}
+
+type FriendResponse struct {
+	UserID  uuid.UUID       `json:"user_id"`
+	Profile *ProfileSummary `json:"profile,omitempty"`
+	Since   string          `json:"since"`
+}
+
+type FriendRequestResponse struct {
+	ID        uuid.UUID       `json:"id"`
+	UserID    uuid.UUID       `json:"user_id"` // The other party
+	Profile   *ProfileSummary `json:"profile,omitempty"`
+	Direction string          `json:"direction"` // "incoming" or "outgoing"
+	CreatedAt string          `json:"created_at"`
+}
+
+type FriendRequestsResponse struct {
+	Incoming []FriendRequestResponse `json:"incoming"`
+	Outgoing []FriendRequestResponse `json:"outgoing"`
+}
+
+// FriendLookupResponse is a user found by username or invite code, with how they relate to the caller
+type FriendLookupResponse struct {
+	UserID  uuid.UUID       `json:"user_id"`
+	Profile *ProfileSummary `json:"profile,omitempty"`
+	Status  string          `json:"status"` // "none", "friends", "incoming", "outgoing" or "self"
+}
+
+// VibeMatch is the compatibility between the caller and a friend
+type VibeMatch struct {
+	FriendID            uuid.UUID `json:"friend_id"`
+	Score               int       `json:"score"`                // 0-100
+	AestheticSimilarity float64   `json:"aesthetic_similarity"` // Cosine similarity of aesthetic mixes, 0-1
+	ScoreCorrelation    *float64  `json:"score_correlation"`    // Pearson r on days you both checked in; null if too few
+	OverlapDays         int       `json:"overlap_days"`
+	SharedStreak        int       `json:"shared_streak"`         // Consecutive days you've both checked in, up to today
+	LongestSharedStreak int       `json:"longest_shared_streak"` // Within the window
+	SharedAesthetic     string    `json:"shared_aesthetic,omitempty"`
+	SharedEmoji         string    `json:"shared_emoji,omitempty"`
+	Explanation         []string  `json:"explanation"`
+	WindowDays          int       `json:"window_days"`
+}
@@ -0,0 +1,19 @@
+package dto
+
+// GlobalStat is an anonymized daily aggregate for a cohort of users
+type GlobalStat struct {
+	Cohort     string             `json:"cohort"`
+	Users      int                `json:"users"`
+	AvgScore   float64            `json:"avg_score"`
+	Aesthetics map[string]float64 `json:"aesthetics"` // Share of check-ins per aesthetic; rare ones fold into "other"
+}
+
+// GlobalComparisonResponse places the user's day alongside everyone else's
+type GlobalComparisonResponse struct {
+	Date       string      `json:"date"`
+	Global     *GlobalStat `json:"global"` // Null until enough users have checked in
+	Cohort     *GlobalStat `json:"cohort"` // The user's region; null when too small to report
+	YourScore  *int        `json:"your_score"`
+	Percentile *float64    `json:"percentile"` // Percent of the day's check-ins the user scored above
+	UpdatedAt  string      `json:"updated_at,omitempty"`
+}
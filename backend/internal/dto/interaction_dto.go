@@ -0,0 +1,23 @@
+package dto
+
+import "github.com/google/uuid"
+
+type SetReactionRequest struct {
+	Emoji string `json:"emoji"`
+}
+
+type ReactionResponse struct {
+	UserID uuid.UUID `json:"user_id"`
+	Emoji  string    `json:"emoji"`
+}
+
+// ReactionsResponse summarizes the reactions on a vibe check
+type ReactionsResponse struct {
+	Counts map[string]int     `json:"counts"`
+	Mine   string             `json:"mine,omitempty"`
+	Data   []ReactionResponse `json:"data"`
+}
+
+type CreateCommentRequest struct {
+	Body string `json:"body"`
+}
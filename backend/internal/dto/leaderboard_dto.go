@@ -0,0 +1,33 @@
+package dto
+
+import "github.com/google/uuid"
+
+type LeaderboardEntry struct {
+	Rank          int             `json:"rank"`
+	UserID        uuid.UUID       `json:"user_id"`
+	Profile       *ProfileSummary `json:"profile,omitempty"`
+	CheckIns      int             `json:"check_ins"` // This week
+	CurrentStreak int             `json:"current_streak"`
+	LongestStreak int             `json:"longest_streak"`
+	IsYou         bool            `json:"is_you"`
+}
+
+// LeaderboardResponse ranks the board by check-ins this week, then current
+// streak, then longest streak. Check-ins reset when the week ends.
+type LeaderboardResponse struct {
+	WeekStart string             `json:"week_start"`
+	WeekEnd   string             `json:"week_end"`
+	ResetsAt  string             `json:"resets_at"`
+	Hidden    bool               `json:"hidden"` // The caller opted out and isn't on others' boards
+	Data      []LeaderboardEntry `json:"data"`
+}
+
+type LeaderboardWinner struct {
+	WeekStart string           `json:"week_start"`
+	WeekEnd   string           `json:"week_end"`
+	Winner    LeaderboardEntry `json:"winner"`
+}
+
+type LeaderboardHistoryResponse struct {
+	Data []LeaderboardWinner `json:"data"` // Newest week first; weeks nobody checked in are skipped
+}
@@ -5,7 +5,7 @@ import "github.com/google/uuid"
 // --- Report DTOs ---
 
 type CreateReportRequest struct {
-	ContentType string `json:"content_type"` // "user", "post", "comment"
+	ContentType string `json:"content_type"` // "user", "post", "comment", "discover"
 	ContentID   string `json:"content_id"`
 	Reason      string `json:"reason"`
 }
@@ -45,4 +45,6 @@ type AppleSignInRequest struct {
 	AuthCode      string `json:"authorization_code"`
 	FullName      string `json:"full_name,omitempty"`
 	Email         string `json:"email,omitempty"` // Only sent on first sign-in
+	InviteCode    string `json:"invite_code,omitempty"`
+	DeviceID      string `json:"device_id,omitempty"`
 }
@@ -0,0 +1,53 @@
+package dto
+
+// PatternGroup is the check-ins that fall on one weekday or time-of-day bucket
+type PatternGroup struct {
+	Label             string   `json:"label"`
+	Checks            int      `json:"checks"`
+	AvgScore          *float64 `json:"avg_score"` // Null when the group is empty
+	Delta             float64  `json:"delta"`     // AvgScore minus the overall average
+	DominantAesthetic string   `json:"dominant_aesthetic,omitempty"`
+	Confidence        string   `json:"confidence"` // none, low, medium or high
+}
+
+// PatternsResponse reports weekday and time-of-day patterns in the user's time zone
+type PatternsResponse struct {
+	Timezone    string         `json:"timezone"`
+	TotalChecks int            `json:"total_checks"`
+	MinChecks   int            `json:"min_checks"` // Needed before any pattern is claimed
+	Ready       bool           `json:"ready"`
+	OverallAvg  float64        `json:"overall_avg"`
+	Weekdays    []PatternGroup `json:"weekdays"`
+	TimesOfDay  []PatternGroup `json:"times_of_day"`
+	Statements  []string       `json:"statements"` // Display-ready, strongest first
+}
+
+// AestheticTransition counts moves from one day's aesthetic to the next day's
+type AestheticTransition struct {
+	From        string  `json:"from"`
+	To          string  `json:"to"`
+	Count       int     `json:"count"`
+	Probability float64 `json:"probability"` // Share of all transitions out of From
+}
+
+// AestheticStay describes how long the user tends to remain in an aesthetic
+type AestheticStay struct {
+	Aesthetic   string  `json:"aesthetic"`
+	Runs        int     `json:"runs"`     // Separate stretches of consecutive days
+	AvgDays     float64 `json:"avg_days"` // Average stretch length
+	LongestDays int     `json:"longest_days"`
+	Moves       int     `json:"moves"`                // Times the next day was a different aesthetic
+	NextMost    string  `json:"next_most,omitempty"`  // Aesthetic most often moved into
+	NextShare   float64 `json:"next_share,omitempty"` // Share of Moves that went to NextMost
+}
+
+// TransitionsResponse is the user's day-to-day aesthetic transition matrix
+type TransitionsResponse struct {
+	Timezone    string                    `json:"timezone"`
+	Days        int                       `json:"days"`        // Local days with a check-in
+	Transitions int                       `json:"transitions"` // Pairs of consecutive days
+	Matrix      map[string]map[string]int `json:"matrix"`      // Matrix[from][to] = count
+	Top         []AestheticTransition     `json:"top"`         // Most common changes, excluding staying put
+	Stays       []AestheticStay           `json:"stays"`
+	Statements  []string                  `json:"statements"`
+}
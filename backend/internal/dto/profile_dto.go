@@ -0,0 +1,42 @@
+package dto
+
+import "github.com/google/uuid"
+
+// UpdateProfileRequest changes only the fields that are set. Setting an
+// avatar emoji or color replaces an uploaded avatar image.
+type UpdateProfileRequest struct {
+	Username    *string `json:"username"`
+	DisplayName *string `json:"display_name"`
+	Bio         *string `json:"bio"`
+	AvatarEmoji *string `json:"avatar_emoji"`
+	AvatarColor *string `json:"avatar_color"`
+}
+
+// Avatar is either an uploaded image or an emoji on a color
+type Avatar struct {
+	ImageURL string `json:"image_url,omitempty"` // Relative to the API host
+	Emoji    string `json:"emoji,omitempty"`
+	Color    string `json:"color,omitempty"`
+}
+
+// ProfileResponse is what other users see
+type ProfileResponse struct {
+	UserID      uuid.UUID `json:"user_id"`
+	Username    string    `json:"username,omitempty"`
+	DisplayName string    `json:"display_name,omitempty"`
+	Bio         string    `json:"bio,omitempty"`
+	Avatar      Avatar    `json:"avatar"`
+}
+
+// MeResponse is the caller's own account and profile
+type MeResponse struct {
+	ProfileResponse
+	Email string `json:"email"`
+}
+
+// ProfileSummary identifies a user in lists such as friends and the feed
+type ProfileSummary struct {
+	Username    string `json:"username,omitempty"`
+	DisplayName string `json:"display_name,omitempty"`
+	Avatar      Avatar `json:"avatar"`
+}
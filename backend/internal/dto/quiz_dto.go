@@ -0,0 +1,34 @@
+package dto
+
+import "github.com/google/uuid"
+
+// QuizAnswerRequest is a single answered question submitted with a check-in
+type QuizAnswerRequest struct {
+	QuestionID uuid.UUID `json:"question_id"`
+	OptionID   uuid.UUID `json:"option_id"`
+}
+
+// --- Admin quiz DTOs ---
+
+type CreateQuizOptionRequest struct {
+	Label      string             `json:"label"`
+	Emoji      string             `json:"emoji,omitempty"`
+	Weights    map[string]float64 `json:"weights"` // aesthetic key -> weight
+	ScoreDelta int                `json:"score_delta"`
+}
+
+type CreateQuizQuestionRequest struct {
+	Prompt  string                    `json:"prompt"`
+	Options []CreateQuizOptionRequest `json:"options"`
+}
+
+// CreateQuizSetRequest publishes a new quiz version
+type CreateQuizSetRequest struct {
+	Name      string                      `json:"name"`
+	Questions []CreateQuizQuestionRequest `json:"questions"`
+	Active    bool                        `json:"active"`
+}
+
+type UpdateQuizSetStatusRequest struct {
+	Active bool `json:"active"`
+}
@@ -0,0 +1,50 @@
+package dto
+
+import "github.com/google/uuid"
+
+// InviteCodeResponse is the caller's personal invite code and its deep link
+type InviteCodeResponse struct {
+	Code      string `json:"code"`
+	InviteURL string `json:"invite_url"`
+	CreatedAt string `json:"created_at"`
+}
+
+// ReferralResponse is one person the caller invited
+type ReferralResponse struct {
+	UserID   uuid.UUID       `json:"user_id"`
+	Profile  *ProfileSummary `json:"profile,omitempty"`
+	Status   string          `json:"status"`    // "pending", "rewarded" or "rejected"
+	CheckIns int             `json:"check_ins"` // Toward CheckInsRequired while pending
+	JoinedAt string          `json:"joined_at"`
+}
+
+// ReferralsResponse is the caller's invite code and referral progress
+type ReferralsResponse struct {
+	InviteCodeResponse
+	CheckInsRequired int                `json:"check_ins_required"`
+	RewardFreezes    int                `json:"reward_freezes"` // Streak freezes each side earns per rewarded referral
+	Rewarded         int                `json:"rewarded"`
+	Data             []ReferralResponse `json:"data"`
+}
+
+type ReferrerStat struct {
+	UserID   uuid.UUID `json:"user_id"`
+	Signups  int       `json:"signups"`
+	Rewarded int       `json:"rewarded"`
+}
+
+// ReferralFunnelResponse is the admin view of referrals over a date range
+type ReferralFunnelResponse struct {
+	From          string         `json:"from"`
+	To            string         `json:"to"`
+	CodesCreated  int            `json:"codes_created"`
+	Signups       int            `json:"signups"`
+	SignupsBy     map[string]int `json:"signups_by_source"`
+	Activated     int            `json:"activated"` // Invitees with at least one check-in
+	Pending       int            `json:"pending"`
+	Rewarded      int            `json:"rewarded"`
+	Rejected      int            `json:"rejected"`
+	RejectReasons map[string]int `json:"reject_reasons"`
+	RewardRate    float64        `json:"reward_rate"` // Rewarded / signups
+	TopReferrers  []ReferrerStat `json:"top_referrers"`
+}
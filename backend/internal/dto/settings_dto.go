@@ -0,0 +1,11 @@
+package dto
+
+// UpdateSettingsRequest changes only the fields that are set
+type UpdateSettingsRequest struct {
+	Timezone            *string `json:"timezone"`
+	NudgesEnabled       *bool   `json:"nudges_enabled"`
+	ShareMoodText       *bool   `json:"share_mood_text"`
+	DefaultVisibility   *string `json:"default_visibility"`
+	DiscoverOptIn       *bool   `json:"discover_opt_in"`
+	HideFromLeaderboard *bool   `json:"hide_from_leaderboard"`
+}
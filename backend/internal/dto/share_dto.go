@@ -0,0 +1,24 @@
+package dto
+
+// CreateShareLinkRequest creates a public link for a vibe check
+type CreateShareLinkRequest struct {
+	ShowMoodText   bool `json:"show_mood_text"`   // Mood text stays hidden unless true
+	ExpiresInHours int  `json:"expires_in_hours"` // 0 means the link never expires
+}
+
+// SharedVibeResponse is the public JSON variant of a share link
+type SharedVibeResponse struct {
+	Token          string `json:"token"`
+	Aesthetic      string `json:"aesthetic"`
+	Emoji          string `json:"emoji"`
+	VibeScore      int    `json:"vibe_score"`
+	Insight        string `json:"insight"`
+	MoodText       string `json:"mood_text,omitempty"` // Only when the owner opted in
+	ColorPrimary   string `json:"color_primary"`
+	ColorSecondary string `json:"color_secondary"`
+	ColorAccent    string `json:"color_accent"`
+	CheckDate      string `json:"check_date"`
+	ShareURL       string `json:"share_url"`
+	CardURL        string `json:"card_url"`
+	SquareCardURL  string `json:"square_card_url"`
+}
@@ -0,0 +1,31 @@
+package dto
+
+import "github.com/google/uuid"
+
+// StreakAuditRequest selects which users to audit. Either user_ids or all must be set.
+type StreakAuditRequest struct {
+	UserIDs []uuid.UUID `json:"user_ids"`
+	All     bool        `json:"all"`
+	Fix     bool        `json:"fix"` // Overwrite drifted streaks with the recomputed values
+}
+
+// StreakSnapshot is the comparable part of a VibeStreak
+type StreakSnapshot struct {
+	CurrentStreak int    `json:"current_streak"`
+	LongestStreak int    `json:"longest_streak"`
+	TotalChecks   int    `json:"total_checks"`
+	LastCheckDate string `json:"last_check_date,omitempty"`
+}
+
+type StreakMismatch struct {
+	UserID   uuid.UUID      `json:"user_id"`
+	Stored   StreakSnapshot `json:"stored"`
+	Expected StreakSnapshot `json:"expected"`
+	Fixed    bool           `json:"fixed"`
+}
+
+type StreakAuditResponse struct {
+	Audited    int              `json:"audited"`
+	Mismatches []StreakMismatch `json:"mismatches"`
+	Fixed      int              `json:"fixed"`
+}
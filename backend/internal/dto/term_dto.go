@@ -0,0 +1,17 @@
+package dto
+
+// TermStat is a word or phrase from mood history, sized for a word cloud
+type TermStat struct {
+	Term     string  `json:"term"`
+	Count    int     `json:"count"`     // Total occurrences
+	Entries  int     `json:"entries"`   // Check-ins containing the term
+	AvgScore float64 `json:"avg_score"` // Average vibe score of those check-ins
+}
+
+type TopTermsResponse struct {
+	From     string     `json:"from,omitempty"`
+	To       string     `json:"to,omitempty"`
+	Language string     `json:"language"`
+	Words    []TermStat `json:"words"`
+	Phrases  []TermStat `json:"phrases"`
+}
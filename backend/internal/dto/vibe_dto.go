@@ -1,8 +1,20 @@
 package dto
 
+import "github.com/google/uuid"
+
 // CreateVibeCheckRequest represents a vibe check-in request
 type CreateVibeCheckRequest struct {
-	MoodText string `json:"mood_text" validate:"required,max=500"`
+	MoodText     string              `json:"mood_text" validate:"required,max=500"`
+	QuizSetID    *uuid.UUID          `json:"quiz_set_id,omitempty"`    // Optional micro-quiz version answered
+	QuizAnswers  []QuizAnswerRequest `json:"quiz_answers,omitempty"`   // Optional micro-quiz answers
+	Visibility   string              `json:"visibility,omitempty"`     // private, circles, friends or public; account default when empty
+	HideMoodText *bool               `json:"hide_mood_text,omitempty"` // Account default when unset
+}
+
+// UpdateCheckVisibilityRequest changes only the fields that are set
+type UpdateCheckVisibilityRequest struct {
+	Visibility   *string `json:"visibility"`
+	HideMoodText *bool   `json:"hide_mood_text"`
 }
 
 // CreateGuestVibeCheckRequest represents a guest vibe check-in request
@@ -34,12 +46,25 @@ type VibeStatsResponse struct {
 	AvgVibeScore  float64 `json:"avg_vibe_score"`
 }
 
-// VibeTrendItem represents a single day's vibe data for trend charts
+// VibeTrendItem is one bucket (day, week or month) of trend chart data.
+// Score fields are null for buckets without a check, so charts show a gap.
 type VibeTrendItem struct {
-	Date      string `json:"date"`       // Format: "2006-01-02"
-	VibeScore int    `json:"vibe_score"` // 0-100, 0 means no check that day
-	Aesthetic string `json:"aesthetic"`  // Empty string if no check
-	Emoji     string `json:"emoji"`      // Empty string if no check
+	Date      string   `json:"date"`       // Bucket start, format: "2006-01-02"
+	VibeScore *float64 `json:"vibe_score"` // Average score in the bucket
+	Min       *int     `json:"min"`
+	Max       *int     `json:"max"`
+	Count     int      `json:"count"`
+	Aesthetic string   `json:"aesthetic"` // Day buckets: that day's aesthetic; otherwise the most frequent
+	Emoji     string   `json:"emoji"`
+}
+
+// HeatmapDay is one calendar cell of the year heatmap
+type HeatmapDay struct {
+	Date      string `json:"date"`
+	VibeScore *int   `json:"vibe_score"` // Null when there was no check that day
+	Aesthetic string `json:"aesthetic,omitempty"`
+	Emoji     string `json:"emoji,omitempty"`
+	Color     string `json:"color,omitempty"` // Aesthetic primary color
 }
 
 // VibeStatsEnhanced contains extended statistics including trend data
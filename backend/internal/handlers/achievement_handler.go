@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/dto"
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/services"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type AchievementHandler struct {
+	achievementService *services.AchievementService
+}
+
+func NewAchievementHandler(achievementService *services.AchievementService) *AchievementHandler {
+	return &AchievementHandler{achievementService: achievementService}
+}
+
+// GetAchievements handles GET /api/achievements
+func (h *AchievementHandler) GetAchievements(c *fiber.Ctx) error {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: true, Message: "Unauthorized",
+		})
+	}
+
+	achievements, err := h.achievementService.GetUserAchievements(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: true, Message: "Failed to fetch achievements",
+		})
+	}
+
+	unlocked := 0
+	for _, a := range achievements {
+		if a.Unlocked {
+			unlocked++
+		}
+	}
+
+	return c.JSON(fiber.Map{
+		"data":     achievements,
+		"unlocked": unlocked,
+		"total":    len(achievements),
+	})
+}
+
+// --- Admin endpoints ---
+
+// ListAchievements returns every badge definition (admin).
+func (h *AchievementHandler) ListAchievements(c *fiber.Ctx) error {
+	achievements, err := h.achievementService.ListAchievements()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: true, Message: "Failed to fetch achievements",
+		})
+	}
+
+	return c.JSON(fiber.Map{"achievements": achievements})
+}
+
+// CreateAchievement adds a new badge rule (admin).
+func (h *AchievementHandler) CreateAchievement(c *fiber.Ctx) error {
+	var req dto.CreateAchievementRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: true, Message: "Invalid request body",
+		})
+	}
+
+	achievement, err := h.achievementService.CreateAchievement(&req)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: true, Message: err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(achievement)
+}
+
+// UpdateAchievement edits a badge rule or takes it out of rotation (admin).
+func (h *AchievementHandler) UpdateAchievement(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: true, Message: "Invalid achievement ID",
+		})
+	}
+
+	var req dto.UpdateAchievementRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: true, Message: "Invalid request body",
+		})
+	}
+
+	achievement, err := h.achievementService.UpdateAchievement(id, &req)
+	if err != nil {
+		if errors.Is(err, services.ErrAchievementNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(dto.ErrorResponse{
+				Error: true, Message: err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: true, Message: err.Error(),
+		})
+	}
+
+	return c.JSON(achievement)
+}
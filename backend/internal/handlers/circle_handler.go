@@ -0,0 +1,249 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/config"
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/dto"
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/services"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type CircleHandler struct {
+	circleService *services.CircleService
+	cfg           *config.Config
+}
+
+func NewCircleHandler(circleService *services.CircleService, cfg *config.Config) *CircleHandler {
+	return &CircleHandler{circleService: circleService, cfg: cfg}
+}
+
+// ListCircles handles GET /api/circles
+func (h *CircleHandler) ListCircles(c *fiber.Ctx) error {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: true, Message: "Unauthorized",
+		})
+	}
+
+	circles, err := h.circleService.ListCircles(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: true, Message: "Failed to fetch circles",
+		})
+	}
+
+	return c.JSON(fiber.Map{"data": circles})
+}
+
+// CreateCircle handles POST /api/circles
+func (h *CircleHandler) CreateCircle(c *fiber.Ctx) error {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: true, Message: "Unauthorized",
+		})
+	}
+
+	var req dto.CreateCircleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: true, Message: "Invalid request body",
+		})
+	}
+
+	circle, err := h.circleService.CreateCircle(userID, &req)
+	if err != nil {
+		return circleError(c, err, "Failed to create circle")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(circle)
+}
+
+// JoinCircle handles POST /api/circles/join
+func (h *CircleHandler) JoinCircle(c *fiber.Ctx) error {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: true, Message: "Unauthorized",
+		})
+	}
+
+	var req dto.JoinCircleRequest
+	if err := c.BodyParser(&req); err != nil || req.Token == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: true, Message: "token is required",
+		})
+	}
+
+	circle, err := h.circleService.Join(userID, req.Token)
+	if err != nil {
+		return circleError(c, err, "Failed to join circle")
+	}
+
+	return c.JSON(circle)
+}
+
+// GetCircle handles GET /api/circles/:id
+func (h *CircleHandler) GetCircle(c *fiber.Ctx) error {
+	userID, circleID, status, err := interactionParams(c)
+	if err != nil {
+		return c.Status(status).JSON(dto.ErrorResponse{Error: true, Message: err.Error()})
+	}
+
+	circle, err := h.circleService.GetCircle(userID, circleID)
+	if err != nil {
+		return circleError(c, err, "Failed to fetch circle")
+	}
+
+	return c.JSON(circle)
+}
+
+// DeleteCircle handles DELETE /api/circles/:id
+func (h *CircleHandler) DeleteCircle(c *fiber.Ctx) error {
+	userID, circleID, status, err := interactionParams(c)
+	if err != nil {
+		return c.Status(status).JSON(dto.ErrorResponse{Error: true, Message: err.Error()})
+	}
+
+	if err := h.circleService.DeleteCircle(userID, circleID); err != nil {
+		return circleError(c, err, "Failed to delete circle")
+	}
+
+	return c.JSON(fiber.Map{"message": "Circle deleted"})
+}
+
+// GetInvite handles GET /api/circles/:id/invite
+func (h *CircleHandler) GetInvite(c *fiber.Ctx) error {
+	userID, circleID, status, err := interactionParams(c)
+	if err != nil {
+		return c.Status(status).JSON(dto.ErrorResponse{Error: true, Message: err.Error()})
+	}
+
+	token, err := h.circleService.GetInvite(userID, circleID)
+	if err != nil {
+		return circleError(c, err, "Failed to fetch invite")
+	}
+
+	return c.JSON(h.inviteResponse(c, token))
+}
+
+// RotateInvite handles POST /api/circles/:id/invite
+func (h *CircleHandler) RotateInvite(c *fiber.Ctx) error {
+	userID, circleID, status, err := interactionParams(c)
+	if err != nil {
+		return c.Status(status).JSON(dto.ErrorResponse{Error: true, Message: err.Error()})
+	}
+
+	token, err := h.circleService.RotateInvite(userID, circleID)
+	if err != nil {
+		return circleError(c, err, "Failed to create invite")
+	}
+
+	return c.JSON(h.inviteResponse(c, token))
+}
+
+// LeaveCircle handles POST /api/circles/:id/leave
+func (h *CircleHandler) LeaveCircle(c *fiber.Ctx) error {
+	userID, circleID, status, err := interactionParams(c)
+	if err != nil {
+		return c.Status(status).JSON(dto.ErrorResponse{Error: true, Message: err.Error()})
+	}
+
+	if err := h.circleService.Leave(userID, circleID); err != nil {
+		return circleError(c, err, "Failed to leave circle")
+	}
+
+	return c.JSON(fiber.Map{"message": "Left circle"})
+}
+
+// KickMember handles DELETE /api/circles/:id/members/:userId
+func (h *CircleHandler) KickMember(c *fiber.Ctx) error {
+	userID, circleID, status, err := interactionParams(c)
+	if err != nil {
+		return c.Status(status).JSON(dto.ErrorResponse{Error: true, Message: err.Error()})
+	}
+
+	memberID, err := uuid.Parse(c.Params("userId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: true, Message: "Invalid user ID",
+		})
+	}
+
+	if err := h.circleService.Kick(userID, circleID, memberID); err != nil {
+		return circleError(c, err, "Failed to remove member")
+	}
+
+	return c.JSON(fiber.Map{"message": "Member removed"})
+}
+
+// GetFeed handles GET /api/circles/:id/feed?cursor=...&limit=20
+func (h *CircleHandler) GetFeed(c *fiber.Ctx) error {
+	userID, circleID, status, err := interactionParams(c)
+	if err != nil {
+		return c.Status(status).JSON(dto.ErrorResponse{Error: true, Message: err.Error()})
+	}
+
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+
+	feed, err := h.circleService.GetFeed(userID, circleID, c.Query("cursor"), limit)
+	if err != nil {
+		return circleError(c, err, "Failed to fetch circle feed")
+	}
+
+	return c.JSON(feed)
+}
+
+// GetToday handles GET /api/circles/:id/today
+func (h *CircleHandler) GetToday(c *fiber.Ctx) error {
+	userID, circleID, status, err := interactionParams(c)
+	if err != nil {
+		return c.Status(status).JSON(dto.ErrorResponse{Error: true, Message: err.Error()})
+	}
+
+	today, err := h.circleService.GetToday(userID, circleID)
+	if err != nil {
+		return circleError(c, err, "Failed to fetch circle board")
+	}
+
+	return c.JSON(today)
+}
+
+// inviteResponse builds the deep link the app opens to join a circle
+func (h *CircleHandler) inviteResponse(c *fiber.Ctx, token string) dto.CircleInviteResponse {
+	base := c.BaseURL()
+	if h.cfg.PublicBaseURL != "" {
+		base = strings.TrimRight(h.cfg.PublicBaseURL, "/")
+	}
+	return dto.CircleInviteResponse{Token: token, InviteURL: base + "/c/" + token}
+}
+
+// circleError maps circle service errors to HTTP statuses
+func circleError(c *fiber.Ctx, err error, fallback string) error {
+	status := fiber.StatusInternalServerError
+	message := fallback
+	switch {
+	case errors.Is(err, services.ErrCircleNotFound),
+		errors.Is(err, services.ErrCircleMemberMissing):
+		status, message = fiber.StatusNotFound, err.Error()
+	case errors.Is(err, services.ErrNotCircleOwner),
+		errors.Is(err, services.ErrCircleUnavailable):
+		status, message = fiber.StatusForbidden, err.Error()
+	case errors.Is(err, services.ErrAlreadyCircleMember):
+		status, message = fiber.StatusConflict, err.Error()
+	case errors.Is(err, services.ErrCircleNameInvalid),
+		errors.Is(err, services.ErrCannotKickSelf),
+		errors.Is(err, services.ErrInvalidFeedCursor):
+		status, message = fiber.StatusBadRequest, err.Error()
+	case errors.Is(err, services.ErrCircleNameRejected),
+		errors.Is(err, services.ErrCircleFull),
+		errors.Is(err, services.ErrCircleLimitReached):
+		status, message = fiber.StatusUnprocessableEntity, err.Error()
+	}
+	return c.Status(status).JSON(dto.ErrorResponse{Error: true, Message: message})
+}
@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/dto"
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/services"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type CompatibilityHandler struct {
+	compatibilityService *services.CompatibilityService
+	cards                *services.CardService
+}
+
+func NewCompatibilityHandler(compatibilityService *services.CompatibilityService, cards *services.CardService) *CompatibilityHandler {
+	return &CompatibilityHandler{compatibilityService: compatibilityService, cards: cards}
+}
+
+// GetMatch handles GET /api/friends/:id/match
+func (h *CompatibilityHandler) GetMatch(c *fiber.Ctx) error {
+	match, status, err := h.loadMatch(c)
+	if err != nil {
+		return c.Status(status).JSON(dto.ErrorResponse{
+			Error: true, Message: err.Error(),
+		})
+	}
+
+	return c.JSON(match)
+}
+
+// GetMatchCard handles GET /api/friends/:id/match/card.png?size=story|square
+func (h *CompatibilityHandler) GetMatchCard(c *fiber.Ctx) error {
+	match, status, err := h.loadMatch(c)
+	if err != nil {
+		return c.Status(status).JSON(dto.ErrorResponse{
+			Error: true, Message: err.Error(),
+		})
+	}
+
+	data, err := h.cards.RenderMatchCard(match, c.Query("size", services.CardSizeStory))
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidCardSize) {
+			return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+				Error: true, Message: err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: true, Message: "Failed to render match card",
+		})
+	}
+
+	c.Set(fiber.HeaderContentType, "image/png")
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	return c.Send(data)
+}
+
+// loadMatch resolves the match for the route's friend, returning the HTTP
+// status to use on failure.
+func (h *CompatibilityHandler) loadMatch(c *fiber.Ctx) (*dto.VibeMatch, int, error) {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return nil, fiber.StatusUnauthorized, errors.New("Unauthorized")
+	}
+
+	friendID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return nil, fiber.StatusBadRequest, errors.New("Invalid user ID")
+	}
+
+	match, err := h.compatibilityService.GetMatch(userID, friendID)
+	switch {
+	case err == nil:
+		return match, fiber.StatusOK, nil
+	case errors.Is(err, services.ErrNotFriends):
+		return nil, fiber.StatusNotFound, err
+	case errors.Is(err, services.ErrNotEnoughMatchData):
+		return nil, fiber.StatusUnprocessableEntity, err
+	}
+	return nil, fiber.StatusInternalServerError, errors.New("Failed to compute vibe match")
+}
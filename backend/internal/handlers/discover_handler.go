@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/dto"
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/services"
+	"github.com/gofiber/fiber/v2"
+)
+
+type DiscoverHandler struct {
+	discoverService *services.DiscoverService
+}
+
+func NewDiscoverHandler(discoverService *services.DiscoverService) *DiscoverHandler {
+	return &DiscoverHandler{discoverService: discoverService}
+}
+
+// GetStream handles GET /api/discover?aesthetic=chill&cursor=...&limit=20
+func (h *DiscoverHandler) GetStream(c *fiber.Ctx) error {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: true, Message: "Unauthorized",
+		})
+	}
+
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+
+	stream, err := h.discoverService.GetStream(userID, c.Query("aesthetic"), c.Query("cursor"), limit)
+	if err != nil {
+		return discoverError(c, err, "Failed to fetch discover stream")
+	}
+
+	return c.JSON(stream)
+}
+
+// SetReaction handles PUT /api/discover/:id/reaction
+func (h *DiscoverHandler) SetReaction(c *fiber.Ctx) error {
+	userID, entryID, status, err := interactionParams(c)
+	if err != nil {
+		return c.Status(status).JSON(dto.ErrorResponse{Error: true, Message: err.Error()})
+	}
+
+	var req dto.SetReactionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: true, Message: "Invalid request body",
+		})
+	}
+
+	if err := h.discoverService.SetReaction(userID, entryID, req.Emoji); err != nil {
+		return discoverError(c, err, "Failed to save reaction")
+	}
+
+	return c.JSON(fiber.Map{"emoji": req.Emoji})
+}
+
+// RemoveReaction handles DELETE /api/discover/:id/reaction
+func (h *DiscoverHandler) RemoveReaction(c *fiber.Ctx) error {
+	userID, entryID, status, err := interactionParams(c)
+	if err != nil {
+		return c.Status(status).JSON(dto.ErrorResponse{Error: true, Message: err.Error()})
+	}
+
+	if err := h.discoverService.RemoveReaction(userID, entryID); err != nil {
+		return discoverError(c, err, "Failed to remove reaction")
+	}
+
+	return c.JSON(fiber.Map{"message": "Reaction removed"})
+}
+
+// discoverError maps discover service errors to HTTP statuses
+func discoverError(c *fiber.Ctx, err error, fallback string) error {
+	status := fiber.StatusInternalServerError
+	message := fallback
+	switch {
+	case errors.Is(err, services.ErrDiscoverEntryNotFound):
+		status, message = fiber.StatusNotFound, err.Error()
+	case errors.Is(err, services.ErrUnknownAesthetic),
+		errors.Is(err, services.ErrInvalidFeedCursor),
+		errors.Is(err, services.ErrInvalidReaction):
+		status, message = fiber.StatusBadRequest, err.Error()
+	}
+	return c.Status(status).JSON(dto.ErrorResponse{Error: true, Message: message})
+}
@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/dto"
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/services"
+	"github.com/gofiber/fiber/v2"
+)
+
+type FeedHandler struct {
+	feedService *services.FeedService
+}
+
+func NewFeedHandler(feedService *services.FeedService) *FeedHandler {
+	return &FeedHandler{feedService: feedService}
+}
+
+// GetFeed handles GET /api/feed?cursor=...&limit=20
+func (h *FeedHandler) GetFeed(c *fiber.Ctx) error {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: true, Message: "Unauthorized",
+		})
+	}
+
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+
+	feed, err := h.feedService.GetFeed(userID, c.Query("cursor"), limit)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidFeedCursor) {
+			return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+				Error: true, Message: err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: true, Message: "Failed to fetch feed",
+		})
+	}
+
+	return c.JSON(feed)
+}
+
+// GetToday handles GET /api/feed/today
+func (h *FeedHandler) GetToday(c *fiber.Ctx) error {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: true, Message: "Unauthorized",
+		})
+	}
+
+	today, err := h.feedService.GetToday(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: true, Message: "Failed to fetch today's feed",
+		})
+	}
+
+	return c.JSON(today)
+}
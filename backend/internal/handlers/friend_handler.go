@@ -0,0 +1,215 @@
+package handlers
+
+import (
+	"errors"
+	"time"
+
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/config"
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/dto"
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/services"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type FriendHandler struct {
+	friendService *services.FriendService
+	cfg           *config.Config
+}
+
+func NewFriendHandler(friendService *services.FriendService, cfg *config.Config) *FriendHandler {
+	return &FriendHandler{friendService: friendService, cfg: cfg}
+}
+
+// ListFriends handles GET /api/friends
+func (h *FriendHandler) ListFriends(c *fiber.Ctx) error {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: true, Message: "Unauthorized",
+		})
+	}
+
+	friends, err := h.friendService.ListFriends(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: true, Message: "Failed to fetch friends",
+		})
+	}
+
+	return c.JSON(fiber.Map{"data": friends})
+}
+
+// RemoveFriend handles DELETE /api/friends/:id
+func (h *FriendHandler) RemoveFriend(c *fiber.Ctx) error {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: true, Message: "Unauthorized",
+		})
+	}
+
+	friendID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: true, Message: "Invalid user ID",
+		})
+	}
+
+	if err := h.friendService.RemoveFriend(userID, friendID); err != nil {
+		return friendError(c, err, "Failed to remove friend")
+	}
+
+	return c.JSON(fiber.Map{"message": "Friend removed"})
+}
+
+// ListRequests handles GET /api/friends/requests
+func (h *FriendHandler) ListRequests(c *fiber.Ctx) error {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: true, Message: "Unauthorized",
+		})
+	}
+
+	requests, err := h.friendService.ListRequests(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: true, Message: "Failed to fetch friend requests",
+		})
+	}
+
+	return c.JSON(requests)
+}
+
+// SendRequest handles POST /api/friends/requests
+func (h *FriendHandler) SendRequest(c *fiber.Ctx) error {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: true, Message: "Unauthorized",
+		})
+	}
+
+	var req dto.SendFriendRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: true, Message: "Invalid request body",
+		})
+	}
+
+	status, err := h.friendService.SendRequest(userID, &req)
+	if err != nil {
+		return friendError(c, err, "Failed to send friend request")
+	}
+
+	if status == services.FriendStatusFriends {
+		return c.JSON(fiber.Map{"status": status, "message": "You are now friends"})
+	}
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"status": status, "message": "Friend request sent"})
+}
+
+// AcceptRequest handles POST /api/friends/requests/:id/accept
+func (h *FriendHandler) AcceptRequest(c *fiber.Ctx) error {
+	return h.respondToRequest(c, h.friendService.AcceptRequest, "Friend request accepted")
+}
+
+// DeclineRequest handles POST /api/friends/requests/:id/decline
+func (h *FriendHandler) DeclineRequest(c *fiber.Ctx) error {
+	return h.respondToRequest(c, h.friendService.DeclineRequest, "Friend request declined")
+}
+
+// CancelRequest handles DELETE /api/friends/requests/:id
+func (h *FriendHandler) CancelRequest(c *fiber.Ctx) error {
+	return h.respondToRequest(c, h.friendService.CancelRequest, "Friend request cancelled")
+}
+
+func (h *FriendHandler) respondToRequest(c *fiber.Ctx, action func(userID, requestID uuid.UUID) error, message string) error {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: true, Message: "Unauthorized",
+		})
+	}
+
+	requestID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: true, Message: "Invalid request ID",
+		})
+	}
+
+	if err := action(userID, requestID); err != nil {
+		return friendError(c, err, "Failed to update friend request")
+	}
+
+	return c.JSON(fiber.Map{"message": message})
+}
+
+// Lookup handles GET /api/friends/lookup?username=vibey or ?code=ABCD2345
+func (h *FriendHandler) Lookup(c *fiber.Ctx) error {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: true, Message: "Unauthorized",
+		})
+	}
+
+	username, code := c.Query("username"), c.Query("code")
+	if username == "" && code == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: true, Message: "username or code is required",
+		})
+	}
+
+	result, err := h.friendService.Lookup(userID, username, code)
+	if err != nil {
+		return friendError(c, err, "Failed to look up user")
+	}
+
+	return c.JSON(result)
+}
+
+// GetInviteCode handles GET /api/friends/code
+func (h *FriendHandler) GetInviteCode(c *fiber.Ctx) error {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: true, Message: "Unauthorized",
+		})
+	}
+
+	invite, err := h.friendService.GetInviteCode(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: true, Message: "Failed to fetch invite code",
+		})
+	}
+
+	return c.JSON(dto.InviteCodeResponse{
+		Code:      invite.Code,
+		InviteURL: inviteURL(c, h.cfg, invite.Code),
+		CreatedAt: invite.CreatedAt.Format(time.RFC3339),
+	})
+}
+
+// friendError maps friend service errors to HTTP statuses
+func friendError(c *fiber.Ctx, err error, fallback string) error {
+	status := fiber.StatusInternalServerError
+	message := fallback
+	switch {
+	case errors.Is(err, services.ErrFriendUserNotFound),
+		errors.Is(err, services.ErrFriendRequestNotFound),
+		errors.Is(err, services.ErrNotFriends):
+		status, message = fiber.StatusNotFound, err.Error()
+	case errors.Is(err, services.ErrAlreadyFriends),
+		errors.Is(err, services.ErrFriendRequestExists):
+		status, message = fiber.StatusConflict, err.Error()
+	case errors.Is(err, services.ErrSelfFriend),
+		errors.Is(err, services.ErrFriendRequestTargetless):
+		status, message = fiber.StatusBadRequest, err.Error()
+	case errors.Is(err, services.ErrFriendLimitReached),
+		errors.Is(err, services.ErrTooManyPendingRequests):
+		status, message = fiber.StatusUnprocessableEntity, err.Error()
+	}
+	return c.Status(status).JSON(dto.ErrorResponse{Error: true, Message: message})
+}
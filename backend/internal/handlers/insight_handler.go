@@ -0,0 +1,241 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/dto"
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/services"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// InsightHandler serves analysis built on top of a user's check-in history
+type InsightHandler struct {
+	patternService  *services.PatternService
+	forecastService *services.ForecastService
+	anomalyService  *services.AnomalyService
+	termService     *services.TermService
+	globalService   *services.GlobalStatsService
+}
+
+func NewInsightHandler(patternService *services.PatternService, forecastService *services.ForecastService, anomalyService *services.AnomalyService, termService *services.TermService, globalService *services.GlobalStatsService) *InsightHandler {
+	return &InsightHandler{patternService: patternService, forecastService: forecastService, anomalyService: anomalyService, termService: termService, globalService: globalService}
+}
+
+// GetPatterns handles GET /api/vibes/patterns
+func (h *InsightHandler) GetPatterns(c *fiber.Ctx) error {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: true, Message: "Unauthorized",
+		})
+	}
+
+	patterns, err := h.patternService.GetPatterns(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: true, Message: "Failed to analyze patterns",
+		})
+	}
+
+	return c.JSON(patterns)
+}
+
+// GetTransitions handles GET /api/vibes/transitions
+func (h *InsightHandler) GetTransitions(c *fiber.Ctx) error {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: true, Message: "Unauthorized",
+		})
+	}
+
+	transitions, err := h.patternService.GetTransitions(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: true, Message: "Failed to analyze transitions",
+		})
+	}
+
+	return c.JSON(transitions)
+}
+
+// GetForecast handles GET /api/vibes/forecast
+func (h *InsightHandler) GetForecast(c *fiber.Ctx) error {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: true, Message: "Unauthorized",
+		})
+	}
+
+	forecast, err := h.forecastService.GetTomorrowForecast(userID)
+	if err != nil {
+		if errors.Is(err, services.ErrNotEnoughHistory) {
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(dto.ErrorResponse{
+				Error: true, Message: err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: true, Message: "Failed to build forecast",
+		})
+	}
+
+	accuracy, err := h.forecastService.GetAccuracy(&userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: true, Message: "Failed to fetch forecast accuracy",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"forecast": forecast,
+		"accuracy": accuracy,
+	})
+}
+
+// GetForecastAccuracy reports accuracy across all users' resolved forecasts (admin).
+func (h *InsightHandler) GetForecastAccuracy(c *fiber.Ctx) error {
+	accuracy, err := h.forecastService.GetAccuracy(nil)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: true, Message: "Failed to fetch forecast accuracy",
+		})
+	}
+
+	return c.JSON(accuracy)
+}
+
+// GetTopTerms handles GET /api/vibes/words?from=2006-01-02&to=2006-01-02&lang=en&limit=20
+func (h *InsightHandler) GetTopTerms(c *fiber.Ctx) error {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: true, Message: "Unauthorized",
+		})
+	}
+
+	var from, to time.Time
+	if v := c.Query("from"); v != "" {
+		if from, err = time.Parse("2006-01-02", v); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+				Error: true, Message: "Invalid from date, expected YYYY-MM-DD",
+			})
+		}
+	}
+	if v := c.Query("to"); v != "" {
+		if to, err = time.Parse("2006-01-02", v); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+				Error: true, Message: "Invalid to date, expected YYYY-MM-DD",
+			})
+		}
+	}
+	if !from.IsZero() && !to.IsZero() && to.Before(from) {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: true, Message: "to must not be before from",
+		})
+	}
+
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+
+	terms, err := h.termService.GetTopTerms(userID, from, to, c.Query("lang"), limit)
+	if err != nil {
+		if errors.Is(err, services.ErrUnsupportedLanguage) {
+			return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+				Error: true, Message: err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: true, Message: "Failed to fetch top words",
+		})
+	}
+
+	return c.JSON(terms)
+}
+
+// GetGlobalComparison handles GET /api/vibes/global?date=2006-01-02
+func (h *InsightHandler) GetGlobalComparison(c *fiber.Ctx) error {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: true, Message: "Unauthorized",
+		})
+	}
+
+	var day time.Time
+	if v := c.Query("date"); v != "" {
+		if day, err = time.Parse("2006-01-02", v); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+				Error: true, Message: "Invalid date, expected YYYY-MM-DD",
+			})
+		}
+	}
+
+	comparison, err := h.globalService.GetComparison(userID, day)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: true, Message: "Failed to fetch global comparison",
+		})
+	}
+
+	return c.JSON(comparison)
+}
+
+// GetInsights handles GET /api/insights
+func (h *InsightHandler) GetInsights(c *fiber.Ctx) error {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: true, Message: "Unauthorized",
+		})
+	}
+
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	insights, unread, err := h.anomalyService.GetInsights(userID, limit)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: true, Message: "Failed to fetch insights",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"data":   insights,
+		"unread": unread,
+	})
+}
+
+// MarkInsightRead handles POST /api/insights/:id/read
+func (h *InsightHandler) MarkInsightRead(c *fiber.Ctx) error {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: true, Message: "Unauthorized",
+		})
+	}
+
+	insightID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: true, Message: "Invalid insight ID",
+		})
+	}
+
+	if err := h.anomalyService.MarkInsightRead(userID, insightID); err != nil {
+		if errors.Is(err, services.ErrInsightNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(dto.ErrorResponse{
+				Error: true, Message: err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: true, Message: "Failed to update insight",
+		})
+	}
+
+	return c.JSON(fiber.Map{"message": "Insight marked as read"})
+}
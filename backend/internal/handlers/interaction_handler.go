@@ -0,0 +1,154 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/dto"
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/services"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type InteractionHandler struct {
+	interactionService *services.InteractionService
+}
+
+func NewInteractionHandler(interactionService *services.InteractionService) *InteractionHandler {
+	return &InteractionHandler{interactionService: interactionService}
+}
+
+// GetReactions handles GET /api/vibes/:id/reactions
+func (h *InteractionHandler) GetReactions(c *fiber.Ctx) error {
+	userID, checkID, status, err := interactionParams(c)
+	if err != nil {
+		return c.Status(status).JSON(dto.ErrorResponse{Error: true, Message: err.Error()})
+	}
+
+	reactions, err := h.interactionService.GetReactions(userID, checkID)
+	if err != nil {
+		return interactionError(c, err, "Failed to fetch reactions")
+	}
+
+	return c.JSON(reactions)
+}
+
+// SetReaction handles PUT /api/vibes/:id/reaction
+func (h *InteractionHandler) SetReaction(c *fiber.Ctx) error {
+	userID, checkID, status, err := interactionParams(c)
+	if err != nil {
+		return c.Status(status).JSON(dto.ErrorResponse{Error: true, Message: err.Error()})
+	}
+
+	var req dto.SetReactionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: true, Message: "Invalid request body",
+		})
+	}
+
+	if err := h.interactionService.SetReaction(userID, checkID, req.Emoji); err != nil {
+		return interactionError(c, err, "Failed to save reaction")
+	}
+
+	return c.JSON(fiber.Map{"emoji": req.Emoji})
+}
+
+// RemoveReaction handles DELETE /api/vibes/:id/reaction
+func (h *InteractionHandler) RemoveReaction(c *fiber.Ctx) error {
+	userID, checkID, status, err := interactionParams(c)
+	if err != nil {
+		return c.Status(status).JSON(dto.ErrorResponse{Error: true, Message: err.Error()})
+	}
+
+	if err := h.interactionService.RemoveReaction(userID, checkID); err != nil {
+		return interactionError(c, err, "Failed to remove reaction")
+	}
+
+	return c.JSON(fiber.Map{"message": "Reaction removed"})
+}
+
+// GetComments handles GET /api/vibes/:id/comments
+func (h *InteractionHandler) GetComments(c *fiber.Ctx) error {
+	userID, checkID, status, err := interactionParams(c)
+	if err != nil {
+		return c.Status(status).JSON(dto.ErrorResponse{Error: true, Message: err.Error()})
+	}
+
+	comments, err := h.interactionService.GetComments(userID, checkID)
+	if err != nil {
+		return interactionError(c, err, "Failed to fetch comments")
+	}
+
+	return c.JSON(fiber.Map{"data": comments})
+}
+
+// AddComment handles POST /api/vibes/:id/comments
+func (h *InteractionHandler) AddComment(c *fiber.Ctx) error {
+	userID, checkID, status, err := interactionParams(c)
+	if err != nil {
+		return c.Status(status).JSON(dto.ErrorResponse{Error: true, Message: err.Error()})
+	}
+
+	var req dto.CreateCommentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: true, Message: "Invalid request body",
+		})
+	}
+
+	comment, err := h.interactionService.AddComment(userID, checkID, req.Body)
+	if err != nil {
+		return interactionError(c, err, "Failed to post comment")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(comment)
+}
+
+// DeleteComment handles DELETE /api/comments/:id
+func (h *InteractionHandler) DeleteComment(c *fiber.Ctx) error {
+	userID, commentID, status, err := interactionParams(c)
+	if err != nil {
+		return c.Status(status).JSON(dto.ErrorResponse{Error: true, Message: err.Error()})
+	}
+
+	if err := h.interactionService.DeleteComment(userID, commentID); err != nil {
+		return interactionError(c, err, "Failed to delete comment")
+	}
+
+	return c.JSON(fiber.Map{"message": "Comment deleted"})
+}
+
+// interactionParams extracts the caller and the :id route param, returning
+// the HTTP status to use on failure.
+func interactionParams(c *fiber.Ctx) (uuid.UUID, uuid.UUID, int, error) {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, fiber.StatusUnauthorized, errors.New("Unauthorized")
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return uuid.Nil, uuid.Nil, fiber.StatusBadRequest, errors.New("Invalid ID")
+	}
+	return userID, id, fiber.StatusOK, nil
+}
+
+// interactionError maps interaction service errors to HTTP statuses
+func interactionError(c *fiber.Ctx, err error, fallback string) error {
+	status := fiber.StatusInternalServerError
+	message := fallback
+	switch {
+	case errors.Is(err, services.ErrVibeCheckNotFound),
+		errors.Is(err, services.ErrCommentNotFound):
+		status, message = fiber.StatusNotFound, err.Error()
+	case errors.Is(err, services.ErrCommentForbidden):
+		status, message = fiber.StatusForbidden, err.Error()
+	case errors.Is(err, services.ErrInvalidReaction),
+		errors.Is(err, services.ErrCommentEmpty),
+		errors.Is(err, services.ErrCommentTooLong):
+		status, message = fiber.StatusBadRequest, err.Error()
+	case errors.Is(err, services.ErrCommentRejected):
+		status, message = fiber.StatusUnprocessableEntity, err.Error()
+	}
+	return c.Status(status).JSON(dto.ErrorResponse{Error: true, Message: message})
+}
@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/dto"
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/services"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type LeaderboardHandler struct {
+	leaderboardService *services.LeaderboardService
+}
+
+func NewLeaderboardHandler(leaderboardService *services.LeaderboardService) *LeaderboardHandler {
+	return &LeaderboardHandler{leaderboardService: leaderboardService}
+}
+
+// GetBoard handles GET /api/leaderboard and GET /api/circles/:id/leaderboard
+func (h *LeaderboardHandler) GetBoard(c *fiber.Ctx) error {
+	userID, circleID, status, err := leaderboardParams(c)
+	if err != nil {
+		return c.Status(status).JSON(dto.ErrorResponse{Error: true, Message: err.Error()})
+	}
+
+	board, err := h.leaderboardService.GetBoard(userID, circleID)
+	if err != nil {
+		return circleError(c, err, "Failed to fetch leaderboard")
+	}
+
+	return c.JSON(board)
+}
+
+// GetHistory handles GET /api/leaderboard/history?weeks=8 and
+// GET /api/circles/:id/leaderboard/history?weeks=8
+func (h *LeaderboardHandler) GetHistory(c *fiber.Ctx) error {
+	userID, circleID, status, err := leaderboardParams(c)
+	if err != nil {
+		return c.Status(status).JSON(dto.ErrorResponse{Error: true, Message: err.Error()})
+	}
+
+	weeks, _ := strconv.Atoi(c.Query("weeks", "8"))
+
+	history, err := h.leaderboardService.GetHistory(userID, circleID, weeks)
+	if err != nil {
+		return circleError(c, err, "Failed to fetch leaderboard history")
+	}
+
+	return c.JSON(history)
+}
+
+// leaderboardParams reads the caller and, on circle routes, the circle ID
+func leaderboardParams(c *fiber.Ctx) (uuid.UUID, *uuid.UUID, int, error) {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return uuid.Nil, nil, fiber.StatusUnauthorized, errors.New("Unauthorized")
+	}
+
+	if c.Params("id") == "" {
+		return userID, nil, fiber.StatusOK, nil
+	}
+	circleID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return uuid.Nil, nil, fiber.StatusBadRequest, errors.New("Invalid circle ID")
+	}
+	return userID, &circleID, fiber.StatusOK, nil
+}
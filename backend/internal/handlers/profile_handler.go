@@ -0,0 +1,176 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/dto"
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/services"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type ProfileHandler struct {
+	profileService *services.ProfileService
+}
+
+func NewProfileHandler(profileService *services.ProfileService) *ProfileHandler {
+	return &ProfileHandler{profileService: profileService}
+}
+
+// GetMe handles GET /api/me
+func (h *ProfileHandler) GetMe(c *fiber.Ctx) error {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: true, Message: "Unauthorized",
+		})
+	}
+
+	me, err := h.profileService.GetMe(userID)
+	if err != nil {
+		return profileError(c, err, "Failed to fetch profile")
+	}
+
+	return c.JSON(me)
+}
+
+// UpdateMe handles PATCH /api/me
+func (h *ProfileHandler) UpdateMe(c *fiber.Ctx) error {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: true, Message: "Unauthorized",
+		})
+	}
+
+	var req dto.UpdateProfileRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: true, Message: "Invalid request body",
+		})
+	}
+
+	me, err := h.profileService.UpdateMe(userID, &req)
+	if err != nil {
+		return profileError(c, err, "Failed to update profile")
+	}
+
+	return c.JSON(me)
+}
+
+// UploadAvatar handles PUT /api/me/avatar (multipart form, field "image")
+func (h *ProfileHandler) UploadAvatar(c *fiber.Ctx) error {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: true, Message: "Unauthorized",
+		})
+	}
+
+	header, err := c.FormFile("image")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: true, Message: "image file is required",
+		})
+	}
+	file, err := header.Open()
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: true, Message: "image file is required",
+		})
+	}
+	defer file.Close()
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: true, Message: "Failed to read image",
+		})
+	}
+
+	me, err := h.profileService.SetAvatarImage(userID, data)
+	if err != nil {
+		return profileError(c, err, "Failed to save avatar")
+	}
+
+	return c.JSON(me)
+}
+
+// RemoveAvatar handles DELETE /api/me/avatar
+func (h *ProfileHandler) RemoveAvatar(c *fiber.Ctx) error {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: true, Message: "Unauthorized",
+		})
+	}
+
+	if err := h.profileService.RemoveAvatarImage(userID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: true, Message: "Failed to remove avatar",
+		})
+	}
+
+	return c.JSON(fiber.Map{"message": "Avatar removed"})
+}
+
+// GetProfile handles GET /api/profiles/:username
+func (h *ProfileHandler) GetProfile(c *fiber.Ctx) error {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: true, Message: "Unauthorized",
+		})
+	}
+
+	profile, err := h.profileService.GetByUsername(userID, c.Params("username"))
+	if err != nil {
+		return profileError(c, err, "Failed to fetch profile")
+	}
+
+	return c.JSON(profile)
+}
+
+// GetAvatar handles GET /api/avatars/:id. Public so image loaders can fetch it;
+// URLs carry a version, so responses cache for a long time.
+func (h *ProfileHandler) GetAvatar(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: true, Message: "Invalid user ID",
+		})
+	}
+
+	image, err := h.profileService.GetAvatarImage(userID)
+	if err != nil {
+		return profileError(c, err, "Failed to fetch avatar")
+	}
+
+	c.Set(fiber.HeaderContentType, "image/jpeg")
+	c.Set(fiber.HeaderCacheControl, "public, max-age=86400")
+	return c.Send(image)
+}
+
+// profileError maps profile service errors to HTTP statuses
+func profileError(c *fiber.Ctx, err error, fallback string) error {
+	status := fiber.StatusInternalServerError
+	message := fallback
+	switch {
+	case errors.Is(err, services.ErrProfileNotFound):
+		status, message = fiber.StatusNotFound, err.Error()
+	case errors.Is(err, services.ErrUsernameTaken):
+		status, message = fiber.StatusConflict, err.Error()
+	case errors.Is(err, services.ErrUsernameInvalid),
+		errors.Is(err, services.ErrDisplayNameTooLong),
+		errors.Is(err, services.ErrBioTooLong),
+		errors.Is(err, services.ErrInvalidAvatarEmoji),
+		errors.Is(err, services.ErrInvalidAvatarColor),
+		errors.Is(err, services.ErrInvalidAvatarImage):
+		status, message = fiber.StatusBadRequest, err.Error()
+	case errors.Is(err, services.ErrUsernameRejected),
+		errors.Is(err, services.ErrDisplayNameRejected),
+		errors.Is(err, services.ErrBioRejected):
+		status, message = fiber.StatusUnprocessableEntity, err.Error()
+	}
+	return c.Status(status).JSON(dto.ErrorResponse{Error: true, Message: message})
+}
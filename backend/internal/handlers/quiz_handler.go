@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"errors"
+	"time"
+
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/dto"
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/services"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type QuizHandler struct {
+	quizService *services.QuizService
+}
+
+func NewQuizHandler(quizService *services.QuizService) *QuizHandler {
+	return &QuizHandler{quizService: quizService}
+}
+
+// GetTodayQuiz handles GET /api/quiz/today
+func (h *QuizHandler) GetTodayQuiz(c *fiber.Ctx) error {
+	quiz, err := h.quizService.GetTodayQuiz(time.Now())
+	if err != nil {
+		if errors.Is(err, services.ErrNoActiveQuiz) {
+			return c.Status(fiber.StatusNotFound).JSON(dto.ErrorResponse{
+				Error: true, Message: err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: true, Message: "Failed to fetch quiz",
+		})
+	}
+
+	return c.JSON(quiz)
+}
+
+// --- Admin endpoints ---
+
+// ListQuizSets returns every quiz version (admin).
+func (h *QuizHandler) ListQuizSets(c *fiber.Ctx) error {
+	sets, err := h.quizService.ListQuizSets()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: true, Message: "Failed to fetch quizzes",
+		})
+	}
+
+	return c.JSON(fiber.Map{"quizzes": sets})
+}
+
+// CreateQuizSet publishes a new quiz version (admin).
+func (h *QuizHandler) CreateQuizSet(c *fiber.Ctx) error {
+	var req dto.CreateQuizSetRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: true, Message: "Invalid request body",
+		})
+	}
+
+	set, err := h.quizService.CreateQuizSet(&req)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: true, Message: err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(set)
+}
+
+// UpdateQuizSetStatus adds or removes a quiz version from rotation (admin).
+func (h *QuizHandler) UpdateQuizSetStatus(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: true, Message: "Invalid quiz ID",
+		})
+	}
+
+	var req dto.UpdateQuizSetStatusRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: true, Message: "Invalid request body",
+		})
+	}
+
+	if err := h.quizService.SetQuizSetActive(id, req.Active); err != nil {
+		if errors.Is(err, services.ErrQuizNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(dto.ErrorResponse{
+				Error: true, Message: err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: true, Message: "Failed to update quiz",
+		})
+	}
+
+	return c.JSON(fiber.Map{"message": "Quiz updated successfully"})
+}
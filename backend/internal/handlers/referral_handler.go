@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"strings"
+	"time"
+
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/config"
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/dto"
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/services"
+	"github.com/gofiber/fiber/v2"
+)
+
+const referralFunnelDefaultDays = 30
+
+type ReferralHandler struct {
+	referralService *services.ReferralService
+	cfg             *config.Config
+}
+
+func NewReferralHandler(referralService *services.ReferralService, cfg *config.Config) *ReferralHandler {
+	return &ReferralHandler{referralService: referralService, cfg: cfg}
+}
+
+// GetReferrals handles GET /api/referrals
+func (h *ReferralHandler) GetReferrals(c *fiber.Ctx) error {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: true, Message: "Unauthorized",
+		})
+	}
+
+	referrals, err := h.referralService.GetReferrals(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: true, Message: "Failed to fetch referrals",
+		})
+	}
+	referrals.InviteURL = inviteURL(c, h.cfg, referrals.Code)
+
+	return c.JSON(referrals)
+}
+
+// GetFunnel handles GET /api/admin/referrals/funnel?from=2006-01-02&to=2006-01-02
+// (defaults to the last 30 days)
+func (h *ReferralHandler) GetFunnel(c *fiber.Ctx) error {
+	to := time.Now().Truncate(24 * time.Hour)
+	from := to.AddDate(0, 0, -(referralFunnelDefaultDays - 1))
+	var err error
+	if v := c.Query("from"); v != "" {
+		if from, err = time.Parse("2006-01-02", v); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+				Error: true, Message: "Invalid from date, expected YYYY-MM-DD",
+			})
+		}
+	}
+	if v := c.Query("to"); v != "" {
+		if to, err = time.Parse("2006-01-02", v); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+				Error: true, Message: "Invalid to date, expected YYYY-MM-DD",
+			})
+		}
+	}
+	if to.Before(from) {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: true, Message: "to must not be before from",
+		})
+	}
+
+	funnel, err := h.referralService.GetFunnel(from, to)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: true, Message: "Failed to fetch referral funnel",
+		})
+	}
+
+	return c.JSON(funnel)
+}
+
+// inviteURL builds the deep link that signs a new user up with an invite code
+func inviteURL(c *fiber.Ctx, cfg *config.Config, code string) string {
+	base := c.BaseURL()
+	if cfg.PublicBaseURL != "" {
+		base = strings.TrimRight(cfg.PublicBaseURL, "/")
+	}
+	return base + "/i/" + code
+}
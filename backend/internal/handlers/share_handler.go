@@ -0,0 +1,266 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"html/template"
+	"strconv"
+	"strings"
+
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/config"
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/dto"
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/models"
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/services"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type ShareHandler struct {
+	shareService *services.ShareService
+	cards        *services.CardService
+	cfg          *config.Config
+}
+
+func NewShareHandler(shareService *services.ShareService, cards *services.CardService, cfg *config.Config) *ShareHandler {
+	return &ShareHandler{shareService: shareService, cards: cards, cfg: cfg}
+}
+
+// sharePageTemplate is the public preview page. Crawlers (iMessage, X, Instagram)
+// read the Open Graph / Twitter tags; humans see the card with an app CTA.
+var sharePageTemplate = template.Must(template.New("share").Parse(`<!DOCTYPE html><html><head><meta charset="utf-8"><meta name="viewport" content="width=device-width,initial-scale=1"><title>{{.Title}} - VibeCheck</title>
+<meta name="description" content="{{.Description}}">
+<meta property="og:type" content="website">
+<meta property="og:site_name" content="VibeCheck">
+<meta property="og:title" content="{{.Title}}">
+<meta property="og:description" content="{{.Description}}">
+<meta property="og:url" content="{{.ShareURL}}">
+<meta property="og:image" content="{{.ImageURL}}">
+<meta property="og:image:type" content="image/png">
+<meta property="og:image:width" content="1080">
+<meta property="og:image:height" content="1080">
+<meta name="twitter:card" content="summary_large_image">
+<meta name="twitter:title" content="{{.Title}}">
+<meta name="twitter:description" content="{{.Description}}">
+<meta name="twitter:image" content="{{.ImageURL}}">
+<style>body{font-family:-apple-system,system-ui,sans-serif;margin:0;padding:24px;background:#09090B;color:#fafafa;text-align:center}img{width:100%;max-width:420px;border-radius:24px}blockquote{max-width:420px;margin:20px auto;font-style:italic;color:#d4d4d8}a.cta{display:inline-block;margin-top:20px;padding:14px 28px;border-radius:999px;background:linear-gradient(90deg,{{.ColorPrimary}},{{.ColorSecondary}});color:#fff;text-decoration:none;font-weight:600}</style></head>
+<body><img src="{{.StoryImageURL}}" alt="{{.Title}}">{{if .MoodText}}<blockquote>&ldquo;{{.MoodText}}&rdquo;</blockquote>{{end}}<br><a class="cta" href="{{.AppURL}}">Check your vibe on VibeCheck</a></body></html>`))
+
+type sharePageData struct {
+	Title          string
+	Description    string
+	MoodText       string
+	ShareURL       string
+	ImageURL       string
+	StoryImageURL  string
+	AppURL         string
+	ColorPrimary   template.CSS
+	ColorSecondary template.CSS
+}
+
+// CreateShareLink handles POST /api/vibes/:id/share-links
+func (h *ShareHandler) CreateShareLink(c *fiber.Ctx) error {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: true, Message: "Unauthorized",
+		})
+	}
+
+	checkID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: true, Message: "Invalid vibe check ID",
+		})
+	}
+
+	var req dto.CreateShareLinkRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: true, Message: "Invalid request body",
+		})
+	}
+
+	link, err := h.shareService.CreateShareLink(userID, checkID, &req)
+	if err != nil {
+		if errors.Is(err, services.ErrVibeCheckNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(dto.ErrorResponse{
+				Error: true, Message: err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: true, Message: err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"link":      link,
+		"share_url": h.baseURL(c) + "/s/" + link.Token,
+	})
+}
+
+// ListShareLinks handles GET /api/vibes/:id/share-links
+func (h *ShareHandler) ListShareLinks(c *fiber.Ctx) error {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: true, Message: "Unauthorized",
+		})
+	}
+
+	checkID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: true, Message: "Invalid vibe check ID",
+		})
+	}
+
+	links, err := h.shareService.ListShareLinks(userID, checkID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: true, Message: "Failed to fetch share links",
+		})
+	}
+
+	return c.JSON(fiber.Map{"data": links})
+}
+
+// RevokeShareLink handles DELETE /api/share-links/:id
+func (h *ShareHandler) RevokeShareLink(c *fiber.Ctx) error {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+			Error: true, Message: "Unauthorized",
+		})
+	}
+
+	linkID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: true, Message: "Invalid share link ID",
+		})
+	}
+
+	if err := h.shareService.RevokeShareLink(userID, linkID); err != nil {
+		if errors.Is(err, services.ErrShareLinkNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(dto.ErrorResponse{
+				Error: true, Message: err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: true, Message: "Failed to revoke share link",
+		})
+	}
+
+	return c.JSON(fiber.Map{"message": "Share link revoked successfully"})
+}
+
+// --- Public endpoints ---
+
+// SharedVibe handles GET /api/share/:token (JSON variant for the app)
+func (h *ShareHandler) SharedVibe(c *fiber.Ctx) error {
+	link, check, err := h.shareService.ResolveShareLink(c.Params("token"), true)
+	if err != nil {
+		return c.Status(shareErrorStatus(err)).JSON(dto.ErrorResponse{
+			Error: true, Message: err.Error(),
+		})
+	}
+
+	return c.JSON(h.sharedVibeResponse(c, link, check))
+}
+
+// SharePage handles GET /s/:token (HTML with Open Graph preview tags)
+func (h *ShareHandler) SharePage(c *fiber.Ctx) error {
+	link, check, err := h.shareService.ResolveShareLink(c.Params("token"), true)
+	if err != nil {
+		c.Set("Content-Type", "text/html; charset=utf-8")
+		return c.Status(shareErrorStatus(err)).SendString(`<!DOCTYPE html><html><head><meta charset="utf-8"><meta name="robots" content="noindex"><title>VibeCheck</title></head><body style="font-family:-apple-system,system-ui,sans-serif;text-align:center;padding:40px;background:#09090B;color:#fafafa"><h1>This vibe is no longer available</h1></body></html>`)
+	}
+
+	resp := h.sharedVibeResponse(c, link, check)
+	description := check.Insight
+	if resp.MoodText != "" {
+		description = "“" + resp.MoodText + "” — " + check.Insight
+	}
+
+	var buf bytes.Buffer
+	if err := sharePageTemplate.Execute(&buf, sharePageData{
+		Title:          strings.TrimSpace(check.Emoji + " " + check.Aesthetic + " · Vibe score " + strconv.Itoa(check.VibeScore)),
+		Description:    description,
+		MoodText:       resp.MoodText,
+		ShareURL:       resp.ShareURL,
+		ImageURL:       resp.SquareCardURL,
+		StoryImageURL:  resp.CardURL,
+		AppURL:         h.baseURL(c),
+		ColorPrimary:   template.CSS(safeHexColor(check.ColorPrimary)),
+		ColorSecondary: template.CSS(safeHexColor(check.ColorSecondary)),
+	}); err != nil {
+		return c.Status(fiber.StatusInternalServerError).SendString("Failed to render page")
+	}
+
+	c.Set("Content-Type", "text/html; charset=utf-8")
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	return c.Send(buf.Bytes())
+}
+
+// SharedCard handles GET /s/:token/card.png. Image fetches don't count as views,
+// since link-preview crawlers request them on their own.
+func (h *ShareHandler) SharedCard(c *fiber.Ctx) error {
+	_, check, err := h.shareService.ResolveShareLink(c.Params("token"), false)
+	if err != nil {
+		return c.Status(shareErrorStatus(err)).JSON(dto.ErrorResponse{
+			Error: true, Message: err.Error(),
+		})
+	}
+
+	return sendVibeCard(c, h.cards, check, "public, max-age=300")
+}
+
+func (h *ShareHandler) sharedVibeResponse(c *fiber.Ctx, link *models.ShareLink, check *models.VibeCheck) dto.SharedVibeResponse {
+	shareURL := h.baseURL(c) + "/s/" + link.Token
+	resp := dto.SharedVibeResponse{
+		Token:          link.Token,
+		Aesthetic:      check.Aesthetic,
+		Emoji:          check.Emoji,
+		VibeScore:      check.VibeScore,
+		Insight:        check.Insight,
+		ColorPrimary:   check.ColorPrimary,
+		ColorSecondary: check.ColorSecondary,
+		ColorAccent:    check.ColorAccent,
+		CheckDate:      check.CheckDate.Format("2006-01-02"),
+		ShareURL:       shareURL,
+		CardURL:        shareURL + "/card.png?size=" + services.CardSizeStory,
+		SquareCardURL:  shareURL + "/card.png?size=" + services.CardSizeSquare,
+	}
+	if link.ShowMoodText {
+		resp.MoodText = check.MoodText
+	}
+	return resp
+}
+
+// baseURL prefers the configured public origin so links stay correct behind proxies
+func (h *ShareHandler) baseURL(c *fiber.Ctx) string {
+	if h.cfg.PublicBaseURL != "" {
+		return strings.TrimRight(h.cfg.PublicBaseURL, "/")
+	}
+	return c.BaseURL()
+}
+
+func shareErrorStatus(err error) int {
+	if errors.Is(err, services.ErrShareLinkExpired) {
+		return fiber.StatusGone
+	}
+	return fiber.StatusNotFound
+}
+
+// safeHexColor only lets "#rrggbb" values into inline CSS
+func safeHexColor(hex string) string {
+	if len(hex) != 7 || hex[0] != '#' {
+		return "#7C3AED"
+	}
+	for _, r := range hex[1:] {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", r) {
+			return "#7C3AED"
+		}
+	}
+	return hex
+}
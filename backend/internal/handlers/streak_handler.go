@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/dto"
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/services"
+	"github.com/gofiber/fiber/v2"
+)
+
+type StreakHandler struct {
+	streakService *services.StreakService
+}
+
+func NewStreakHandler(streakService *services.StreakService) *StreakHandler {
+	return &StreakHandler{streakService: streakService}
+}
+
+// AuditStreaks recomputes streaks from check history and reports drift (admin).
+// Large runs are better done with the streakaudit CLI.
+func (h *StreakHandler) AuditStreaks(c *fiber.Ctx) error {
+	var req dto.StreakAuditRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: true, Message: "Invalid request body",
+		})
+	}
+	if len(req.UserIDs) == 0 && !req.All {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: true, Message: "Provide user_ids or set all to true",
+		})
+	}
+	if len(req.UserIDs) > 0 && req.All {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Error: true, Message: "user_ids and all are mutually exclusive",
+		})
+	}
+
+	report, err := h.streakService.AuditStreaks(services.StreakAuditOptions{
+		UserIDs: req.UserIDs,
+		Fix:     req.Fix,
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: true, Message: "Failed to audit streaks",
+		})
+	}
+
+	return c.JSON(report)
+}
@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/dto"
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/models"
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/services"
+	"github.com/gofiber/fiber/v2"
+)
+
+type SummaryHandler struct {
+	summaryService *services.SummaryService
+	cards          *services.CardService
+}
+
+func NewSummaryHandler(summaryService *services.SummaryService, cards *services.CardService) *SummaryHandler {
+	return &SummaryHandler{summaryService: summaryService, cards: cards}
+}
+
+// GetSummary handles GET /api/vibes/summaries/:period?date=YYYY-MM-DD
+func (h *SummaryHandler) GetSummary(c *fiber.Ctx) error {
+	summary, status, err := h.loadSummary(c)
+	if err != nil {
+		return c.Status(status).JSON(dto.ErrorResponse{
+			Error: true, Message: err.Error(),
+		})
+	}
+
+	return c.JSON(summary)
+}
+
+// GetSummaryCard handles GET /api/vibes/summaries/:period/card.png?date=YYYY-MM-DD&size=story|square
+func (h *SummaryHandler) GetSummaryCard(c *fiber.Ctx) error {
+	summary, status, err := h.loadSummary(c)
+	if err != nil {
+		return c.Status(status).JSON(dto.ErrorResponse{
+			Error: true, Message: err.Error(),
+		})
+	}
+
+	size := c.Query("size", services.CardSizeStory)
+	etag := ""
+	if !summary.InProgress {
+		etag = fmt.Sprintf(`"%s-%s"`, summary.ID, size)
+		if c.Get(fiber.HeaderIfNoneMatch) == etag {
+			return c.SendStatus(fiber.StatusNotModified)
+		}
+	}
+
+	data, err := h.cards.RenderSummaryCard(summary, size)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidCardSize) {
+			return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+				Error: true, Message: err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Error: true, Message: "Failed to render summary card",
+		})
+	}
+
+	c.Set(fiber.HeaderContentType, "image/png")
+	if etag != "" {
+		c.Set(fiber.HeaderCacheControl, "private, max-age=86400")
+		c.Set(fiber.HeaderETag, etag)
+	} else {
+		c.Set(fiber.HeaderCacheControl, "no-store")
+	}
+	return c.Send(data)
+}
+
+// loadSummary resolves the authenticated user's summary for the route params,
+// returning the HTTP status to use on failure.
+func (h *SummaryHandler) loadSummary(c *fiber.Ctx) (*models.VibeSummary, int, error) {
+	userID, err := extractUserID(c)
+	if err != nil {
+		return nil, fiber.StatusUnauthorized, errors.New("Unauthorized")
+	}
+
+	var day time.Time
+	if raw := c.Query("date"); raw != "" {
+		if day, err = time.Parse("2006-01-02", raw); err != nil {
+			return nil, fiber.StatusBadRequest, errors.New("date must be YYYY-MM-DD")
+		}
+	}
+
+	summary, err := h.summaryService.GetSummary(userID, c.Params("period"), day)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidSummaryPeriod):
+			return nil, fiber.StatusBadRequest, err
+		case errors.Is(err, services.ErrNoSummaryData):
+			return nil, fiber.StatusNotFound, err
+		}
+		return nil, fiber.StatusInternalServerError, errors.New("Failed to build summary")
+	}
+	return summary, fiber.StatusOK, nil
+}
@@ -1,9 +1,13 @@
 package handlers
 
 import (
+	"errors"
+	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/dto"
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/models"
 	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/services"
 	"github.com/gofiber/fiber/v2"
 	"github.com/golang-jwt/jwt/v5"
@@ -12,10 +16,11 @@ import (
 
 type VibeHandler struct {
 	service *services.VibeService
+	cards   *services.CardService
 }
 
-func NewVibeHandler(service *services.VibeService) *VibeHandler {
-	return &VibeHandler{service: service}
+func NewVibeHandler(service *services.VibeService, cards *services.CardService) *VibeHandler {
+	return &VibeHandler{service: service, cards: cards}
 }
 
 // CreateVibeCheck handles POST /api/vibes
@@ -32,9 +37,13 @@ func (h *VibeHandler) CreateVibeCheck(c *fiber.Ctx) error {
 		})
 	}
 
-	check, err := h.service.CreateVibeCheck(userID, req.MoodText)
+	check, err := h.service.CreateVibeCheck(userID, &req)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+		status := fiber.StatusBadRequest
+		if errors.Is(err, services.ErrAlreadyCheckedIn) {
+			status = fiber.StatusConflict
+		}
+		return c.Status(status).JSON(fiber.Map{
 			"error":   true,
 			"message": err.Error(),
 		})
@@ -60,6 +69,48 @@ func (h *VibeHandler) GetTodayCheck(c *fiber.Ctx) error {
 	return c.JSON(check)
 }
 
+// UpdateVisibility handles PATCH /api/vibes/:id/visibility
+func (h *VibeHandler) UpdateVisibility(c *fiber.Ctx) error {
+	userToken := c.Locals("user").(*jwt.Token)
+	claims := userToken.Claims.(jwt.MapClaims)
+	userID, _ := uuid.Parse(claims["sub"].(string))
+
+	checkID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid vibe check ID",
+		})
+	}
+
+	var req dto.UpdateCheckVisibilityRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid request body",
+		})
+	}
+
+	check, err := h.service.UpdateVisibility(userID, checkID, &req)
+	if err != nil {
+		status := fiber.StatusInternalServerError
+		message := "Failed to update visibility"
+		switch {
+		case errors.Is(err, services.ErrVibeCheckNotFound):
+			status, message = fiber.StatusNotFound, "Vibe check not found"
+		case errors.Is(err, services.ErrInvalidVisibility):
+			status, message = fiber.StatusBadRequest, err.Error()
+		}
+		return c.Status(status).JSON(fiber.Map{
+			"error":   true,
+			"message": message,
+		})
+	}
+	h.cards.Invalidate(check.ID)
+
+	return c.JSON(check)
+}
+
 // GetVibeHistory handles GET /api/vibes/history
 func (h *VibeHandler) GetVibeHistory(c *fiber.Ctx) error {
 	userToken := c.Locals("user").(*jwt.Token)
@@ -138,25 +189,74 @@ func (h *VibeHandler) GetVibeStats(c *fiber.Ctx) error {
 	return c.JSON(stats)
 }
 
-// GetVibeTrend handles GET /api/vibes/trend
-func (h *VibeHandler) GetVibeTrend(c *fiber.Ctx) error {
+// RepairStreak handles POST /api/vibes/streak/repair
+func (h *VibeHandler) RepairStreak(c *fiber.Ctx) error {
 	userToken := c.Locals("user").(*jwt.Token)
 	claims := userToken.Claims.(jwt.MapClaims)
 	userID, _ := uuid.Parse(claims["sub"].(string))
 
-	days, err := strconv.Atoi(c.Query("days", "7"))
+	streak, err := h.service.RepairStreak(userID)
 	if err != nil {
-		days = 7
-	}
-	if days < 1 {
-		days = 7
+		status := fiber.StatusInternalServerError
+		message := "Failed to repair streak"
+		switch {
+		case errors.Is(err, services.ErrNoRepairableStreak):
+			status, message = fiber.StatusConflict, err.Error()
+		case errors.Is(err, services.ErrNoRepairTokens):
+			status, message = fiber.StatusPaymentRequired, err.Error()
+		}
+		return c.Status(status).JSON(fiber.Map{
+			"error":   true,
+			"message": message,
+		})
 	}
-	if days > 30 {
-		days = 30
+
+	return c.JSON(streak)
+}
+
+// GetVibeTrend handles GET /api/vibes/trend?days=N or ?from=YYYY-MM-DD&to=YYYY-MM-DD,
+// with optional bucket=day|week|month
+func (h *VibeHandler) GetVibeTrend(c *fiber.Ctx) error {
+	userToken := c.Locals("user").(*jwt.Token)
+	claims := userToken.Claims.(jwt.MapClaims)
+	userID, _ := uuid.Parse(claims["sub"].(string))
+
+	bucket := c.Query("bucket", services.TrendBucketDay)
+	to := time.Now().Truncate(24 * time.Hour)
+	var from time.Time
+
+	if c.Query("from") != "" || c.Query("to") != "" {
+		var err error
+		if from, err = time.Parse("2006-01-02", c.Query("from")); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   true,
+				"message": "from must be YYYY-MM-DD",
+			})
+		}
+		if raw := c.Query("to"); raw != "" {
+			if to, err = time.Parse("2006-01-02", raw); err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   true,
+					"message": "to must be YYYY-MM-DD",
+				})
+			}
+		}
+	} else {
+		days, err := strconv.Atoi(c.Query("days", "7"))
+		if err != nil || days < 1 {
+			days = 7
+		}
+		from = to.AddDate(0, 0, -(days - 1))
 	}
 
-	trendData, err := h.service.GetVibeTrend(userID, days)
+	trendData, err := h.service.GetVibeTrend(userID, from, to, bucket)
 	if err != nil {
+		if errors.Is(err, services.ErrInvalidTrendBucket) || errors.Is(err, services.ErrInvalidTrendRange) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   true,
+				"message": err.Error(),
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error":   true,
 			"message": "Failed to fetch vibe trend",
@@ -167,8 +267,100 @@ func (h *VibeHandler) GetVibeTrend(c *fiber.Ctx) error {
 		"success": true,
 		"data":    trendData,
 		"meta": fiber.Map{
-			"days":      days,
+			"from":      from.Format("2006-01-02"),
+			"to":        to.Format("2006-01-02"),
+			"bucket":    bucket,
+			"days":      int(to.Sub(from).Hours()/24) + 1,
 			"data_type": "vibe_trend",
 		},
 	})
 }
+
+// GetYearHeatmap handles GET /api/vibes/heatmap?year=YYYY
+func (h *VibeHandler) GetYearHeatmap(c *fiber.Ctx) error {
+	userToken := c.Locals("user").(*jwt.Token)
+	claims := userToken.Claims.(jwt.MapClaims)
+	userID, _ := uuid.Parse(claims["sub"].(string))
+
+	year, err := strconv.Atoi(c.Query("year", strconv.Itoa(time.Now().Year())))
+	if err != nil || year < 2000 || year > 9999 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid year",
+		})
+	}
+
+	days, err := h.service.GetYearHeatmap(userID, year)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to fetch heatmap",
+		})
+	}
+
+	checked := 0
+	for _, d := range days {
+		if d.VibeScore != nil {
+			checked++
+		}
+	}
+
+	return c.JSON(fiber.Map{
+		"year":         year,
+		"data":         days,
+		"checked_days": checked,
+	})
+}
+
+// GetVibeCard handles GET /api/vibes/:id/card.png?size=story|square
+func (h *VibeHandler) GetVibeCard(c *fiber.Ctx) error {
+	userToken := c.Locals("user").(*jwt.Token)
+	claims := userToken.Claims.(jwt.MapClaims)
+	userID, _ := uuid.Parse(claims["sub"].(string))
+
+	checkID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Invalid vibe check ID",
+		})
+	}
+
+	check, err := h.service.GetVibeCheck(userID, checkID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Vibe check not found",
+		})
+	}
+
+	return sendVibeCard(c, h.cards, check, "private, max-age=300")
+}
+
+// sendVibeCard renders a check's card at the requested size and writes it as a PNG
+func sendVibeCard(c *fiber.Ctx, cards *services.CardService, check *models.VibeCheck, cacheControl string) error {
+	size := c.Query("size", services.CardSizeStory)
+	etag := fmt.Sprintf(`"%s-%s-%d"`, check.ID, size, check.UpdatedAt.UnixNano())
+	if c.Get(fiber.HeaderIfNoneMatch) == etag {
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+
+	data, err := cards.RenderVibeCard(check, size)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidCardSize) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   true,
+				"message": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Failed to render vibe card",
+		})
+	}
+
+	c.Set(fiber.HeaderContentType, "image/png")
+	c.Set(fiber.HeaderCacheControl, cacheControl)
+	c.Set(fiber.HeaderETag, etag)
+	return c.Send(data)
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Achievement is a badge definition. Rules are data: a badge unlocks once the
+// named metric, computed with Params, reaches Threshold. New badges can be
+// added by combining existing metrics without a code change.
+type Achievement struct {
+	ID          uuid.UUID          `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	Key         string             `gorm:"size:50;not null;uniqueIndex" json:"key"`
+	Name        string             `gorm:"size:100;not null" json:"name"`
+	Description string             `gorm:"size:255" json:"description"`
+	Icon        string             `gorm:"size:10" json:"icon"`
+	Metric      string             `gorm:"size:50;not null" json:"metric"`                     // e.g. total_checks, longest_streak, early_checks
+	Params      map[string]float64 `gorm:"type:jsonb;serializer:json" json:"params,omitempty"` // Metric-specific knobs, e.g. {"before_hour": 8}
+	Threshold   int                `gorm:"not null" json:"threshold"`
+	SortOrder   int                `gorm:"default:0" json:"sort_order"`
+	Active      bool               `gorm:"not null;index" json:"active"`
+	CreatedAt   time.Time          `json:"created_at"`
+	UpdatedAt   time.Time          `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt     `gorm:"index" json:"-"`
+}
+
+// UserAchievement records when a user unlocked a badge
+type UserAchievement struct {
+	ID            uuid.UUID   `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	UserID        uuid.UUID   `gorm:"type:uuid;not null;uniqueIndex:idx_user_achievement" json:"user_id"`
+	AchievementID uuid.UUID   `gorm:"type:uuid;not null;uniqueIndex:idx_user_achievement" json:"achievement_id"`
+	VibeCheckID   *uuid.UUID  `gorm:"type:uuid" json:"vibe_check_id,omitempty"` // Check-in that triggered the unlock
+	UnlockedAt    time.Time   `gorm:"not null" json:"unlocked_at"`
+	Achievement   Achievement `gorm:"foreignKey:AchievementID" json:"achievement"`
+}
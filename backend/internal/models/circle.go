@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Circle roles
+const (
+	CircleRoleOwner  = "owner"
+	CircleRoleMember = "member"
+)
+
+// Circle is a small private group whose members share a daily mood board
+type Circle struct {
+	ID          uuid.UUID      `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	OwnerID     uuid.UUID      `gorm:"type:uuid;not null;index" json:"owner_id"`
+	Name        string         `gorm:"size:50;not null" json:"name"`
+	Emoji       string         `gorm:"size:16" json:"emoji,omitempty"`
+	InviteToken string         `gorm:"size:32;not null;uniqueIndex" json:"-"` // Rotated by the owner to revoke old links
+	MaxMembers  int            `gorm:"not null" json:"max_members"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+type CircleMember struct {
+	ID       uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"-"`
+	CircleID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_circle_members_pair" json:"-"`
+	UserID   uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_circle_members_pair;index" json:"user_id"`
+	Role     string    `gorm:"size:10;not null" json:"role"`
+	JoinedAt time.Time `gorm:"not null" json:"joined_at"`
+}
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Discover entry statuses
+const (
+	DiscoverStatusApproved = "approved" // Passed screening and shows in the stream
+	DiscoverStatusRejected = "rejected" // Failed author screening; never shown
+	DiscoverStatusHidden   = "hidden"   // Auto-hidden by reports until an admin reviews it
+	DiscoverStatusRemoved  = "removed"  // Taken down by an admin
+)
+
+// DiscoverEntry puts a check in the anonymous discover stream. Its ID is
+// separate from the check's, so an entry can't be traced back to its author.
+type DiscoverEntry struct {
+	ID          uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	VibeCheckID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex" json:"-"`
+	UserID      uuid.UUID `gorm:"type:uuid;not null;index" json:"-"`
+	MoodText    string    `gorm:"size:500;not null;default:''" json:"-"` // Screened copy; empty when the text didn't pass
+	Status      string    `gorm:"size:20;not null;index" json:"status"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// DiscoverReaction is one viewer's reaction on a discover entry. Reactors
+// stay anonymous; only counts are shown.
+type DiscoverReaction struct {
+	ID        uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	EntryID   uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_discover_reactions_pair" json:"entry_id"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_discover_reactions_pair;index" json:"-"`
+	Emoji     string    `gorm:"size:16;not null" json:"emoji"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
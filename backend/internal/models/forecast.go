@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// VibeForecast is a predicted score for one day, resolved against the actual
+// check-in once it happens so forecast accuracy can be tracked.
+type VibeForecast struct {
+	ID                 uuid.UUID  `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	UserID             uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_forecast_user_date" json:"user_id"`
+	TargetDate         time.Time  `gorm:"type:date;not null;uniqueIndex:idx_forecast_user_date" json:"target_date"`
+	Model              string     `gorm:"size:30;not null" json:"model"` // Forecasting method/version, so methods can be compared
+	PredictedScore     float64    `json:"predicted_score"`
+	Lower              float64    `json:"lower"` // 80% band
+	Upper              float64    `json:"upper"`
+	PredictedAesthetic string     `gorm:"size:100" json:"predicted_aesthetic"`
+	AestheticChance    float64    `json:"aesthetic_chance"` // 0-1
+	ActualScore        *int       `json:"actual_score,omitempty"`
+	ActualAesthetic    string     `gorm:"size:100" json:"actual_aesthetic,omitempty"`
+	AbsError           *float64   `json:"abs_error,omitempty"`
+	WithinBand         *bool      `json:"within_band,omitempty"`
+	AestheticHit       *bool      `json:"aesthetic_hit,omitempty"`
+	ResolvedAt         *time.Time `gorm:"index" json:"resolved_at,omitempty"`
+	CreatedAt          time.Time  `json:"created_at"`
+}
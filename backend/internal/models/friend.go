@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FriendRequest is a pending request from Sender to Receiver. Accepted,
+// declined and cancelled requests are deleted.
+type FriendRequest struct {
+	ID         uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	SenderID   uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_friend_requests_pair" json:"sender_id"`
+	ReceiverID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_friend_requests_pair;index" json:"receiver_id"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// Friendship is one direction of an accepted friendship; every friendship is
+// stored as two rows so "friends of X" is a single indexed lookup.
+type Friendship struct {
+	ID        uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"-"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_friendships_pair" json:"-"`
+	FriendID  uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_friendships_pair;index" json:"friend_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// InviteCode is a user's personal code, shared so others can find and add them
+type InviteCode struct {
+	ID        uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"-"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null;uniqueIndex" json:"-"`
+	Code      string    `gorm:"size:16;not null;uniqueIndex" json:"code"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Global stat cohorts
+const (
+	CohortGlobal       = "global"
+	CohortRegionPrefix = "region:" // Followed by the first part of the IANA time zone, e.g. "region:Europe"
+)
+
+// GlobalVibeStat is an anonymized aggregate of one day's check-ins across a
+// cohort. Rows only exist for cohorts large enough to hide any individual.
+type GlobalVibeStat struct {
+	ID         uuid.UUID          `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"-"`
+	Date       time.Time          `gorm:"type:date;not null;uniqueIndex:idx_global_stats_date_cohort" json:"date"`
+	Cohort     string             `gorm:"size:50;not null;uniqueIndex:idx_global_stats_date_cohort" json:"cohort"`
+	Users      int                `gorm:"not null" json:"users"`
+	AvgScore   float64            `gorm:"not null" json:"avg_score"`
+	Aesthetics map[string]float64 `gorm:"type:jsonb;serializer:json" json:"aesthetics"` // Share of check-ins per aesthetic
+	Histogram  []int              `gorm:"type:jsonb;serializer:json" json:"-"`          // Check-ins per 10-point score band, for percentiles
+	Noised     bool               `gorm:"not null" json:"noised"`
+	ComputedAt time.Time          `gorm:"not null" json:"computed_at"`
+}
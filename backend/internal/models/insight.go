@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// VibeBaseline is a user's typical score and variability, tracked as
+// exponentially weighted moving averages updated on every check-in.
+type VibeBaseline struct {
+	ID         uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"-"`
+	UserID     uuid.UUID `gorm:"type:uuid;not null;uniqueIndex" json:"user_id"`
+	Mean       float64   `json:"mean"`
+	Variance   float64   `json:"variance"`
+	Volatility float64   `json:"volatility"` // Typical absolute change between consecutive check-ins
+	LastScore  float64   `json:"last_score"`
+	Samples    int       `json:"samples"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// Insight is an in-app message generated from a user's history, such as a
+// gentle nudge after a sustained drop.
+type Insight struct {
+	ID          uuid.UUID  `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	UserID      uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
+	Type        string     `gorm:"size:30;not null" json:"type"` // sustained_drop, volatility
+	Title       string     `gorm:"size:100;not null" json:"title"`
+	Message     string     `gorm:"size:500;not null" json:"message"`
+	VibeCheckID *uuid.UUID `gorm:"type:uuid" json:"vibe_check_id,omitempty"`
+	ReadAt      *time.Time `json:"read_at,omitempty"`
+	CreatedAt   time.Time  `gorm:"index" json:"created_at"`
+}
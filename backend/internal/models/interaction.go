@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Reaction is a user's emoji reaction to a vibe check; one per user per check
+type Reaction struct {
+	ID          uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"-"`
+	VibeCheckID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_reactions_check_user" json:"vibe_check_id"`
+	UserID      uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_reactions_check_user;index" json:"user_id"`
+	Emoji       string    `gorm:"size:16;not null" json:"emoji"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// Comment is a short note left on a friend's vibe check. Reports against it
+// use content_type "comment" and its ID.
+type Comment struct {
+	ID          uuid.UUID      `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	VibeCheckID uuid.UUID      `gorm:"type:uuid;not null;index" json:"vibe_check_id"`
+	UserID      uuid.UUID      `gorm:"type:uuid;not null;index" json:"user_id"`
+	Body        string         `gorm:"size:280;not null" json:"body"`
+	CreatedAt   time.Time      `json:"created_at"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
+}
@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LeaderboardResult is one user's frozen standing for a closed leaderboard
+// week (Monday to Sunday, UTC). Boards are per viewer, so past winners are
+// picked from these rows among the viewer's current friends and circle-mates.
+type LeaderboardResult struct {
+	ID            uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"-"`
+	WeekStart     time.Time `gorm:"type:date;not null;uniqueIndex:idx_leaderboard_result_week" json:"week_start"`
+	UserID        uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_leaderboard_result_week;index" json:"user_id"`
+	CheckIns      int       `gorm:"not null" json:"check_ins"`
+	CurrentStreak int       `gorm:"not null" json:"current_streak"` // As of the week's close
+	LongestStreak int       `gorm:"not null" json:"longest_streak"`
+	CreatedAt     time.Time `json:"created_at"`
+}
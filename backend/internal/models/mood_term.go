@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MoodTerm is one word (N=1) or two-word phrase (N=2) found in a check's mood
+// text. Terms are indexed once per check so word stats never rescan history.
+type MoodTerm struct {
+	ID          uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"-"`
+	UserID      uuid.UUID `gorm:"type:uuid;not null;index:idx_mood_terms_user_date" json:"-"`
+	CheckDate   time.Time `gorm:"type:date;not null;index:idx_mood_terms_user_date" json:"-"`
+	VibeCheckID uuid.UUID `gorm:"type:uuid;not null;index" json:"-"`
+	VibeScore   int       `gorm:"not null" json:"-"`
+	Term        string    `gorm:"size:100;not null" json:"term"`
+	N           int       `gorm:"type:smallint;not null" json:"n"`
+	Occurrences int       `gorm:"not null" json:"occurrences"` // Times the term appears in this check
+}
@@ -0,0 +1,23 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Profile is the public face of a user. A missing row means no username yet.
+type Profile struct {
+	ID              uuid.UUID  `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"-"`
+	UserID          uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex" json:"user_id"`
+	Username        *string    `gorm:"size:20" json:"username"`      // As the user typed it
+	UsernameKey     *string    `gorm:"size:20;uniqueIndex" json:"-"` // Lowercased for case-insensitive uniqueness
+	DisplayName     string     `gorm:"size:50;not null;default:''" json:"display_name"`
+	Bio             string     `gorm:"size:160;not null;default:''" json:"bio"`
+	AvatarEmoji     string     `gorm:"size:16;not null;default:''" json:"avatar_emoji"`
+	AvatarColor     string     `gorm:"size:7;not null;default:''" json:"avatar_color"` // #RRGGBB
+	AvatarImage     []byte     `gorm:"type:bytea" json:"-"`                            // 256x256 JPEG, re-encoded server-side
+	AvatarUpdatedAt *time.Time `json:"-"`
+	CreatedAt       time.Time  `json:"-"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// QuizSet is a versioned, immutable set of micro-quiz questions.
+// Sets are never edited once published; a new version is created instead so
+// that answers stored against older checks keep their original meaning.
+type QuizSet struct {
+	ID        uuid.UUID      `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	Version   int            `gorm:"not null;uniqueIndex" json:"version"`
+	Name      string         `gorm:"size:100;not null" json:"name"`
+	Active    bool           `gorm:"default:true;index" json:"active"` // Active sets take part in daily rotation
+	Questions []QuizQuestion `gorm:"foreignKey:QuizSetID" json:"questions,omitempty"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// QuizQuestion is a single question within a QuizSet
+type QuizQuestion struct {
+	ID        uuid.UUID    `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	QuizSetID uuid.UUID    `gorm:"type:uuid;not null;index" json:"quiz_set_id"`
+	Position  int          `gorm:"not null" json:"position"`
+	Prompt    string       `gorm:"size:255;not null" json:"prompt"`
+	Options   []QuizOption `gorm:"foreignKey:QuestionID" json:"options,omitempty"`
+}
+
+// QuizOption is an answer option. Weights maps models.Aesthetics keys to how
+// strongly the option pulls toward that aesthetic; ScoreDelta nudges the vibe score.
+type QuizOption struct {
+	ID         uuid.UUID          `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	QuestionID uuid.UUID          `gorm:"type:uuid;not null;index" json:"question_id"`
+	Position   int                `gorm:"not null" json:"position"`
+	Label      string             `gorm:"size:100;not null" json:"label"`
+	Emoji      string             `gorm:"size:10" json:"emoji,omitempty"`
+	Weights    map[string]float64 `gorm:"type:jsonb;serializer:json" json:"-"`
+	ScoreDelta int                `gorm:"default:0" json:"-"`
+}
+
+// QuizAnswer records which option a user picked for a question on a given check
+type QuizAnswer struct {
+	ID          uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	VibeCheckID uuid.UUID `gorm:"type:uuid;not null;index" json:"vibe_check_id"`
+	QuizSetID   uuid.UUID `gorm:"type:uuid;not null;index" json:"quiz_set_id"`
+	QuestionID  uuid.UUID `gorm:"type:uuid;not null" json:"question_id"`
+	OptionID    uuid.UUID `gorm:"type:uuid;not null" json:"option_id"`
+	CreatedAt   time.Time `json:"created_at"`
+}
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Referral statuses
+const (
+	ReferralStatusPending  = "pending"  // Signed up; waiting on qualifying check-ins
+	ReferralStatusRewarded = "rewarded" // Qualified and both sides were rewarded
+	ReferralStatusRejected = "rejected" // Failed an anti-abuse check; the friendship stays, no reward
+)
+
+// Referral records who invited whom. Rows outlive account deletion so the
+// same device or email can't farm rewards by signing up again.
+type Referral struct {
+	ID           uuid.UUID  `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	ReferrerID   uuid.UUID  `gorm:"type:uuid;not null;index" json:"referrer_id"`
+	InviteeID    uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex" json:"invitee_id"`
+	Code         string     `gorm:"size:16;not null" json:"code"`
+	Source       string     `gorm:"size:20;not null" json:"source"` // "email" or "apple"
+	DeviceID     string     `gorm:"size:100;index" json:"-"`
+	Status       string     `gorm:"size:20;not null;index" json:"status"`
+	RejectReason string     `gorm:"size:30" json:"reject_reason,omitempty"`
+	RewardedAt   *time.Time `json:"rewarded_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
@@ -10,7 +10,7 @@ import (
 type Report struct {
 	ID          uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
 	ReporterID  uuid.UUID `gorm:"type:uuid;not null;index" json:"reporter_id"`
-	ContentType string    `gorm:"not null;size:50" json:"content_type"` // "user", "post", "comment"
+	ContentType string    `gorm:"not null;size:50" json:"content_type"` // "user", "post", "comment", "discover"
 	ContentID   string    `gorm:"not null;size:255;index" json:"content_id"`
 	Reason      string    `gorm:"not null;size:500" json:"reason"`
 	Status      string    `gorm:"not null;default:'pending';size:50" json:"status"` // pending, reviewed, actioned, dismissed
@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserSettings holds per-user preferences. A missing row means defaults.
+type UserSettings struct {
+	ID                  uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"-"`
+	UserID              uuid.UUID `gorm:"type:uuid;not null;uniqueIndex" json:"-"`
+	Timezone            string    `gorm:"size:64;not null;default:'UTC'" json:"timezone"`               // IANA name, e.g. "Europe/Istanbul"
+	NudgesEnabled       bool      `gorm:"not null;default:true" json:"nudges_enabled"`                  // Supportive insights after drops
+	ShareMoodText       bool      `gorm:"not null;default:false" json:"share_mood_text"`                // Default for new checks: others see mood text, not just the vibe
+	DefaultVisibility   string    `gorm:"size:10;not null;default:'friends'" json:"default_visibility"` // Visibility given to new checks
+	DiscoverOptIn       bool      `gorm:"not null;default:false" json:"discover_opt_in"`                // Public checks appear anonymously in discover
+	HideFromLeaderboard bool      `gorm:"not null;default:false" json:"hide_from_leaderboard"`          // Left off friends' and circles' streak leaderboards
+	CreatedAt           time.Time `json:"-"`
+	UpdatedAt           time.Time `json:"updated_at"`
+}
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ShareLink is a revocable, optionally expiring public link to a single VibeCheck
+type ShareLink struct {
+	ID           uuid.UUID  `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	Token        string     `gorm:"uniqueIndex;not null;size:64" json:"token"`
+	VibeCheckID  uuid.UUID  `gorm:"type:uuid;not null;index" json:"vibe_check_id"`
+	UserID       uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
+	ShowMoodText bool       `gorm:"default:false" json:"show_mood_text"` // Owner opted in to exposing mood text
+	ExpiresAt    *time.Time `json:"expires_at,omitempty"`
+	Revoked      bool       `gorm:"default:false" json:"revoked"`
+	ViewCount    int64      `gorm:"default:0" json:"view_count"`
+	LastViewedAt *time.Time `json:"last_viewed_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+	VibeCheck    VibeCheck  `gorm:"foreignKey:VibeCheckID" json:"-"`
+	User         User       `gorm:"foreignKey:UserID" json:"-"`
+}
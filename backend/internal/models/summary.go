@@ -0,0 +1,59 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Summary periods
+const (
+	SummaryPeriodWeek  = "week"
+	SummaryPeriodMonth = "month"
+	SummaryPeriodYear  = "year"
+)
+
+// VibeSummary is a "Vibe Wrapped" recap of one week, month or year. Rows are
+// persisted once the period has closed and never change afterwards.
+type VibeSummary struct {
+	ID                uuid.UUID   `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	UserID            uuid.UUID   `gorm:"type:uuid;not null;uniqueIndex:idx_vibe_summary_period" json:"user_id"`
+	Period            string      `gorm:"size:10;not null;uniqueIndex:idx_vibe_summary_period" json:"period"`
+	PeriodStart       time.Time   `gorm:"type:date;not null;uniqueIndex:idx_vibe_summary_period" json:"period_start"`
+	PeriodEnd         time.Time   `gorm:"type:date;not null" json:"period_end"`
+	CheckCount        int         `gorm:"not null" json:"check_count"`
+	AvgScore          float64     `json:"avg_score"`
+	PrevAvgScore      *float64    `json:"prev_avg_score"` // Nil when the previous period had no checks
+	DominantAesthetic string      `gorm:"size:100" json:"dominant_aesthetic"`
+	DominantEmoji     string      `gorm:"size:10" json:"dominant_emoji"`
+	BestDay           *SummaryDay `gorm:"type:jsonb;serializer:json" json:"best_day"`
+	WorstDay          *SummaryDay `gorm:"type:jsonb;serializer:json" json:"worst_day"`
+	LongestStreak     int         `json:"longest_streak"` // Longest run of consecutive check-ins inside the period
+	TopWords          []WordCount `gorm:"type:jsonb;serializer:json" json:"top_words"`
+	TagEffects        []TagEffect `gorm:"type:jsonb;serializer:json" json:"tag_effects"`
+	InProgress        bool        `gorm:"-" json:"in_progress"` // Live preview of a period that hasn't closed
+	CreatedAt         time.Time   `json:"created_at"`
+}
+
+// SummaryDay is a single check-in highlighted in a summary
+type SummaryDay struct {
+	Date      string `json:"date"`
+	VibeScore int    `json:"vibe_score"`
+	Aesthetic string `json:"aesthetic"`
+	Emoji     string `json:"emoji"`
+}
+
+// WordCount is a word and how often it appeared in mood text
+type WordCount struct {
+	Word  string `json:"word"`
+	Count int    `json:"count"`
+}
+
+// TagEffect compares the average score of check-ins carrying a tag (a
+// micro-quiz answer) with the period average.
+type TagEffect struct {
+	Tag      string  `json:"tag"`
+	Checks   int     `json:"checks"`
+	AvgScore float64 `json:"avg_score"`
+	Delta    float64 `json:"delta"`
+}
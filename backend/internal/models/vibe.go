@@ -10,7 +10,7 @@ import (
 // VibeCheck represents a daily vibe/mood check
 type VibeCheck struct {
 	ID          uuid.UUID      `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
-	UserID      *uuid.UUID     `gorm:"type:uuid;index" json:"user_id"`
+	UserID      *uuid.UUID     `gorm:"type:uuid;index;uniqueIndex:idx_vibe_checks_user_date,where:deleted_at IS NULL" json:"user_id"`
 	DeviceID    *string        `gorm:"type:varchar(100);index" json:"device_id,omitempty"`
 	MoodText    string         `gorm:"size:500" json:"mood_text"`
 	Aesthetic   string         `gorm:"size:100" json:"aesthetic"`
@@ -20,23 +20,61 @@ type VibeCheck struct {
 	VibeScore   int            `gorm:"default:50" json:"vibe_score"`
 	Emoji       string         `gorm:"size:10" json:"emoji"`
 	Insight     string         `gorm:"size:500" json:"insight"`
-	CheckDate   time.Time      `gorm:"type:date;not null" json:"check_date"`
+	QuizSetID   *uuid.UUID     `gorm:"type:uuid" json:"quiz_set_id,omitempty"` // Quiz version answered with this check, if any
+	Visibility   string        `gorm:"size:10;not null;default:'friends'" json:"visibility"` // Who else can read it; see CheckVisibilities
+	HideMoodText bool          `gorm:"not null;default:false" json:"hide_mood_text"`         // Others see the vibe but not the words
+	CheckDate   time.Time      `gorm:"type:date;not null;uniqueIndex:idx_vibe_checks_user_date,where:deleted_at IS NULL" json:"check_date"` // One check per user per day
+	TermsIndexedAt *time.Time  `json:"-"` // When its mood terms were written; nil until indexed, even if it has none
 	CreatedAt   time.Time      `json:"created_at"`
 	UpdatedAt   time.Time      `json:"updated_at"`
 	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
+
+	NewAchievements []UserAchievement `gorm:"-" json:"new_achievements,omitempty"` // Unlocked by this check-in (create response only)
+	Nudge           *Insight          `gorm:"-" json:"nudge,omitempty"`            // Supportive insight raised by this check-in (create response only)
+}
+
+// Check visibility levels, narrowest first. Each level also admits the
+// audiences of the levels before it, so circle members see "friends" checks.
+const (
+	VisibilityPrivate = "private" // Only the owner
+	VisibilityCircles = "circles" // Members of the owner's circles
+	VisibilityFriends = "friends" // Friends and circle members
+	VisibilityPublic  = "public"  // Any signed-in user
+)
+
+// CheckVisibilities is the set of valid visibility levels
+var CheckVisibilities = map[string]bool{
+	VisibilityPrivate: true,
+	VisibilityCircles: true,
+	VisibilityFriends: true,
+	VisibilityPublic:  true,
 }
 
 // VibeStreak tracks user's vibe check streak
 type VibeStreak struct {
-	ID             uuid.UUID      `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
-	UserID         uuid.UUID      `gorm:"type:uuid;not null;uniqueIndex" json:"user_id"`
-	CurrentStreak  int            `gorm:"default:0" json:"current_streak"`
-	LongestStreak  int            `gorm:"default:0" json:"longest_streak"`
-	TotalChecks    int            `gorm:"default:0" json:"total_checks"`
-	LastCheckDate  time.Time      `gorm:"type:date" json:"last_check_date"`
-	CreatedAt      time.Time      `json:"created_at"`
-	UpdatedAt      time.Time      `json:"updated_at"`
-	DeletedAt      gorm.DeletedAt `gorm:"index" json:"-"`
+	ID               uuid.UUID      `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	UserID           uuid.UUID      `gorm:"type:uuid;not null;uniqueIndex" json:"user_id"`
+	CurrentStreak    int            `gorm:"default:0" json:"current_streak"`
+	LongestStreak    int            `gorm:"default:0" json:"longest_streak"`
+	TotalChecks      int            `gorm:"default:0" json:"total_checks"`
+	LastCheckDate    time.Time      `gorm:"type:date" json:"last_check_date"`
+	FreezesAvailable int            `gorm:"default:0" json:"freezes_available"` // Auto-consumed to cover missed days
+	RepairsAvailable int            `gorm:"default:0" json:"repairs_available"` // Restore a broken streak within the repair window
+	RepairableStreak int            `gorm:"default:0" json:"repairable_streak"` // Streak length lost at the last break
+	RepairDeadline   *time.Time     `json:"repair_deadline,omitempty"`
+	CreatedAt        time.Time      `json:"created_at"`
+	UpdatedAt        time.Time      `json:"updated_at"`
+	DeletedAt        gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// StreakEvent records freeze and repair inventory changes for a user's streak
+type StreakEvent struct {
+	ID        uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null;index" json:"user_id"`
+	Type      string    `gorm:"size:30;not null" json:"type"`         // freeze_earned, freeze_granted, freeze_used, repair_earned, repair_purchased, repair_used
+	EventDate time.Time `gorm:"type:date;not null" json:"event_date"` // For freeze_used, the missed day that was covered
+	Note      string    `gorm:"size:255" json:"note,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
 }
 
 // Aesthetic presets
@@ -18,6 +18,22 @@ func Setup(
 	moderationHandler *handlers.ModerationHandler,
 	vibeHandler *handlers.VibeHandler,
 	legalHandler *handlers.LegalHandler,
+	quizHandler *handlers.QuizHandler,
+	shareHandler *handlers.ShareHandler,
+	streakHandler *handlers.StreakHandler,
+	achievementHandler *handlers.AchievementHandler,
+	summaryHandler *handlers.SummaryHandler,
+	settingsHandler *handlers.SettingsHandler,
+	insightHandler *handlers.InsightHandler,
+	friendHandler *handlers.FriendHandler,
+	feedHandler *handlers.FeedHandler,
+	compatibilityHandler *handlers.CompatibilityHandler,
+	interactionHandler *handlers.InteractionHandler,
+	circleHandler *handlers.CircleHandler,
+	profileHandler *handlers.ProfileHandler,
+	discoverHandler *handlers.DiscoverHandler,
+	referralHandler *handlers.ReferralHandler,
+	leaderboardHandler *handlers.LeaderboardHandler,
 ) {
 	api := app.Group("/api")
 
@@ -35,6 +51,17 @@ func Setup(
 	auth.Post("/refresh", authHandler.Refresh)
 	auth.Post("/apple", authHandler.AppleSignIn) // Sign in with Apple (Guideline 4.8)
 
+	// Daily micro-quiz (public, same quiz for guests and users)
+	api.Get("/quiz/today", quizHandler.GetTodayQuiz)
+
+	// Public share links (no auth; token is the capability)
+	api.Get("/share/:token", shareHandler.SharedVibe) // JSON variant for the app
+	app.Get("/s/:token", shareHandler.SharePage)      // HTML with Open Graph tags
+	app.Get("/s/:token/card.png", shareHandler.SharedCard)
+
+	// Avatar images (public; URLs are versioned)
+	api.Get("/avatars/:id", profileHandler.GetAvatar)
+
 	// Guest vibe check (public, rate limited by device)
 	api.Post("/vibes/guest", vibeHandler.CreateGuestVibeCheck)
 
@@ -43,6 +70,13 @@ func Setup(
 	protected.Post("/auth/logout", authHandler.Logout)
 	protected.Delete("/auth/account", authHandler.DeleteAccount) // Account deletion (Guideline 5.1.1)
 
+	// Profile (protected)
+	protected.Get("/me", profileHandler.GetMe)
+	protected.Patch("/me", profileHandler.UpdateMe)             // Username, display name, bio, emoji avatar
+	protected.Put("/me/avatar", profileHandler.UploadAvatar)    // Multipart "image", PNG or JPEG
+	protected.Delete("/me/avatar", profileHandler.RemoveAvatar) // Back to the emoji avatar
+	protected.Get("/profiles/:username", profileHandler.GetProfile)
+
 	// Moderation - User endpoints (protected)
 	protected.Post("/reports", moderationHandler.CreateReport)     // Report content (Guideline 1.2)
 	protected.Post("/blocks", moderationHandler.BlockUser)         // Block user (Guideline 1.2)
@@ -50,16 +84,108 @@ func Setup(
 
 	// VibeCheck - Daily vibe check-ins (protected)
 	vibes := protected.Group("/vibes")
-	vibes.Post("", vibeHandler.CreateVibeCheck)       // Create daily vibe check
-	vibes.Get("/today", vibeHandler.GetTodayCheck)    // Get today's vibe
-	vibes.Get("/history", vibeHandler.GetVibeHistory) // Get vibe history
-	vibes.Get("/trend", vibeHandler.GetVibeTrend)     // Get vibe trend for charts
-	vibes.Get("/stats", vibeHandler.GetVibeStats)     // Get stats & streaks
+	vibes.Post("", vibeHandler.CreateVibeCheck)            // Create daily vibe check
+	vibes.Get("/today", vibeHandler.GetTodayCheck)         // Get today's vibe
+	vibes.Get("/history", vibeHandler.GetVibeHistory)      // Get vibe history
+	vibes.Get("/trend", vibeHandler.GetVibeTrend)          // Get vibe trend for charts
+	vibes.Get("/heatmap", vibeHandler.GetYearHeatmap)      // Year calendar heatmap
+	vibes.Get("/stats", vibeHandler.GetVibeStats)          // Get stats & streaks
+	vibes.Post("/streak/repair", vibeHandler.RepairStreak) // Restore a broken streak within 48h
+	vibes.Get("/:id/card.png", vibeHandler.GetVibeCard)    // Rendered shareable card (story/square)
+	vibes.Post("/:id/share-links", shareHandler.CreateShareLink)
+	vibes.Get("/:id/share-links", shareHandler.ListShareLinks)
+	vibes.Patch("/:id/visibility", vibeHandler.UpdateVisibility) // private, circles, friends or public; hide mood text
+	protected.Delete("/share-links/:id", shareHandler.RevokeShareLink)
+
+	// Vibe Wrapped - week, month and year summaries (protected)
+	vibes.Get("/summaries/:period", summaryHandler.GetSummary)
+	vibes.Get("/summaries/:period/card.png", summaryHandler.GetSummaryCard)
+
+	// Insights - analysis of check-in history (protected)
+	vibes.Get("/patterns", insightHandler.GetPatterns)       // Weekday and time-of-day patterns
+	vibes.Get("/transitions", insightHandler.GetTransitions) // Day-to-day aesthetic transition matrix
+	vibes.Get("/forecast", insightHandler.GetForecast)       // Tomorrow's vibe with uncertainty band
+	vibes.Get("/words", insightHandler.GetTopTerms)          // Top words and phrases for a word cloud
+	vibes.Get("/global", insightHandler.GetGlobalComparison) // Anonymized comparison with everyone's day
+	protected.Get("/insights", insightHandler.GetInsights)   // Supportive nudges (opt out via settings)
+	protected.Post("/insights/:id/read", insightHandler.MarkInsightRead)
+
+	// Settings (protected)
+	protected.Get("/settings", settingsHandler.GetSettings)
+	protected.Patch("/settings", settingsHandler.UpdateSettings)
+
+	// Friends (protected)
+	friends := protected.Group("/friends")
+	friends.Get("", friendHandler.ListFriends)
+	friends.Get("/code", friendHandler.GetInviteCode) // Personal invite code others can add you by
+	friends.Get("/lookup", friendHandler.Lookup)      // Find a user by username or invite code
+	friends.Get("/requests", friendHandler.ListRequests)
+	friends.Post("/requests", friendHandler.SendRequest) // By user_id, username or invite code; accepts a crossed request
+	friends.Post("/requests/:id/accept", friendHandler.AcceptRequest)
+	friends.Post("/requests/:id/decline", friendHandler.DeclineRequest)
+	friends.Delete("/requests/:id", friendHandler.CancelRequest)
+	friends.Delete("/:id", friendHandler.RemoveFriend)
+	friends.Get("/:id/match", compatibilityHandler.GetMatch)              // Vibe compatibility, 0-100
+	friends.Get("/:id/match/card.png", compatibilityHandler.GetMatchCard) // Shareable match card (story/square)
+
+	// Referrals - invite links that pay out streak freezes (protected)
+	protected.Get("/referrals", referralHandler.GetReferrals) // Invite link and each invitee's progress
+
+	// Reactions and comments on friends' checks (protected)
+	vibes.Get("/:id/reactions", interactionHandler.GetReactions)
+	vibes.Put("/:id/reaction", interactionHandler.SetReaction) // One reaction per user, replaced on change
+	vibes.Delete("/:id/reaction", interactionHandler.RemoveReaction)
+	vibes.Get("/:id/comments", interactionHandler.GetComments)
+	vibes.Post("/:id/comments", interactionHandler.AddComment)          // Filtered by moderation on write
+	protected.Delete("/comments/:id", interactionHandler.DeleteComment) // Author or check owner; report via /reports
+
+	// Friends' feed (protected)
+	protected.Get("/feed", feedHandler.GetFeed)
+	protected.Get("/feed/today", feedHandler.GetToday) // Who has and hasn't checked in today
+
+	// Discover - anonymous public stream for users who opt in (protected)
+	// Entries are reported via POST /api/reports with content_type "discover"
+	discover := protected.Group("/discover")
+	discover.Get("", discoverHandler.GetStream) // Filter with ?aesthetic=chill
+	discover.Put("/:id/reaction", discoverHandler.SetReaction)
+	discover.Delete("/:id/reaction", discoverHandler.RemoveReaction)
+
+	// Circles - small private groups with a shared mood board (protected)
+	circles := protected.Group("/circles")
+	circles.Get("", circleHandler.ListCircles)
+	circles.Post("", circleHandler.CreateCircle)
+	circles.Post("/join", circleHandler.JoinCircle) // With the token from an invite link
+	circles.Get("/:id", circleHandler.GetCircle)
+	circles.Delete("/:id", circleHandler.DeleteCircle)
+	circles.Get("/:id/invite", circleHandler.GetInvite)     // Owner only
+	circles.Post("/:id/invite", circleHandler.RotateInvite) // New token; old links stop working
+	circles.Post("/:id/leave", circleHandler.LeaveCircle)
+	circles.Delete("/:id/members/:userId", circleHandler.KickMember)
+	circles.Get("/:id/feed", circleHandler.GetFeed)
+	circles.Get("/:id/today", circleHandler.GetToday) // Average score, aesthetic mix, who's in
+	circles.Get("/:id/leaderboard", leaderboardHandler.GetBoard)
+	circles.Get("/:id/leaderboard/history", leaderboardHandler.GetHistory)
+
+	// Leaderboard - friends and circle-mates, reset weekly (protected; opt out via settings)
+	protected.Get("/leaderboard", leaderboardHandler.GetBoard)           // Check-ins this week, current and longest streak
+	protected.Get("/leaderboard/history", leaderboardHandler.GetHistory) // Past weekly winners
+
+	// Achievements (protected)
+	protected.Get("/achievements", achievementHandler.GetAchievements) // Unlocked badges + progress toward locked ones
 
 	// Admin moderation panel (protected + admin role required)
 	admin := api.Group("/admin", middleware.JWTProtected(cfg), middleware.AdminRequired(db))
 	admin.Get("/moderation/reports", moderationHandler.ListReports)
 	admin.Put("/moderation/reports/:id", moderationHandler.ActionReport)
+	admin.Get("/quizzes", quizHandler.ListQuizSets)
+	admin.Post("/quizzes", quizHandler.CreateQuizSet)          // Publish a new quiz version
+	admin.Put("/quizzes/:id", quizHandler.UpdateQuizSetStatus) // Add/remove from rotation
+	admin.Post("/streaks/audit", streakHandler.AuditStreaks)   // Recompute streaks from history, optionally fix
+	admin.Get("/achievements", achievementHandler.ListAchievements)
+	admin.Post("/achievements", achievementHandler.CreateAchievement) // New badge from an existing metric
+	admin.Put("/achievements/:id", achievementHandler.UpdateAchievement)
+	admin.Get("/forecasts/accuracy", insightHandler.GetForecastAccuracy)
+	admin.Get("/referrals/funnel", referralHandler.GetFunnel) // Signups, activation, rewards and abuse rejections
 
 	// Webhooks (verified by auth header, not JWT)
 	webhooks := api.Group("/webhooks")
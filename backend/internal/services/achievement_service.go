@@ -0,0 +1,368 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/dto"
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrAchievementNotFound = errors.New("achievement not found")
+	ErrUnknownMetric       = errors.New("unknown achievement metric")
+)
+
+// achievementMetric computes a user's current value for a metric. Params come
+// from the achievement definition, so one metric can back many badges.
+type achievementMetric func(db *gorm.DB, userID uuid.UUID, params map[string]float64) (int, error)
+
+// achievementMetrics is the vocabulary badge rules are written in. Adding a
+// metric needs code; adding a badge built from these does not.
+var achievementMetrics = map[string]achievementMetric{
+	"total_checks":        metricTotalChecks,
+	"current_streak":      metricCurrentStreak,
+	"longest_streak":      metricLongestStreak,
+	"distinct_aesthetics": metricDistinctAesthetics,
+	"early_checks":        metricEarlyChecks,
+	"high_score_checks":   metricHighScoreChecks,
+	"comebacks":           metricComebacks,
+}
+
+// defaultAchievements are seeded on startup; existing keys are left untouched
+var defaultAchievements = []dto.CreateAchievementRequest{
+	{Key: "first_checkin", Name: "First Vibe", Description: "Complete your first vibe check", Icon: "✨", Metric: "total_checks", Threshold: 1},
+	{Key: "streak_7", Name: "Week Streak", Description: "Check in 7 days in a row", Icon: "🔥", Metric: "longest_streak", Threshold: 7},
+	{Key: "streak_30", Name: "Month Streak", Description: "Check in 30 days in a row", Icon: "🌟", Metric: "longest_streak", Threshold: 30},
+	{Key: "streak_100", Name: "Centurion", Description: "Check in 100 days in a row", Icon: "💯", Metric: "longest_streak", Threshold: 100},
+	{Key: "all_aesthetics", Name: "Aesthetic Collector", Description: "Collect all ten aesthetics", Icon: "🎨", Metric: "distinct_aesthetics", Threshold: len(models.Aesthetics)},
+	{Key: "early_bird", Name: "Early Bird", Description: "Check in before 8am five times", Icon: "🌅", Metric: "early_checks", Params: map[string]float64{"before_hour": 8}, Threshold: 5},
+	{Key: "comeback", Name: "Comeback Kid", Description: "Bounce back with a great vibe after a low week", Icon: "🌈", Metric: "comebacks", Params: map[string]float64{"days": 7, "min_checks": 3, "low_avg": 40, "high_score": 70}, Threshold: 1},
+}
+
+type AchievementService struct {
+	db *gorm.DB
+}
+
+func NewAchievementService(db *gorm.DB) *AchievementService {
+	return &AchievementService{db: db}
+}
+
+// SeedDefaults inserts any default achievement whose key does not exist yet
+func (s *AchievementService) SeedDefaults() error {
+	for i, req := range defaultAchievements {
+		achievement := achievementFromRequest(&req)
+		achievement.SortOrder = i
+		achievement.Active = true
+		if err := s.db.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "key"}}, DoNothing: true}).
+			Create(&achievement).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Evaluate unlocks every active achievement the user now qualifies for and
+// returns the new unlocks. checkID is the check-in that triggered evaluation.
+func (s *AchievementService) Evaluate(userID uuid.UUID, checkID *uuid.UUID) ([]models.UserAchievement, error) {
+	locked, err := s.lockedAchievements(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	values := newMetricCache(s.db, userID)
+	var unlocked []models.UserAchievement
+	for _, a := range locked {
+		value, err := values.get(a.Metric, a.Params)
+		if err != nil {
+			return nil, err
+		}
+		if value < a.Threshold {
+			continue
+		}
+
+		ua := models.UserAchievement{
+			UserID:        userID,
+			AchievementID: a.ID,
+			VibeCheckID:   checkID,
+			UnlockedAt:    time.Now(),
+		}
+		result := s.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&ua)
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		if result.RowsAffected > 0 {
+			ua.Achievement = a
+			unlocked = append(unlocked, ua)
+		}
+	}
+
+	return unlocked, nil
+}
+
+// GetUserAchievements lists active badges plus any retired ones the user
+// already holds, with progress toward those still locked.
+func (s *AchievementService) GetUserAchievements(userID uuid.UUID) ([]dto.AchievementResponse, error) {
+	var held []models.UserAchievement
+	if err := s.db.Preload("Achievement").Where("user_id = ?", userID).Find(&held).Error; err != nil {
+		return nil, err
+	}
+	heldByID := make(map[uuid.UUID]models.UserAchievement, len(held))
+	for _, ua := range held {
+		heldByID[ua.AchievementID] = ua
+	}
+
+	var achievements []models.Achievement
+	if err := s.db.Where("active = ?", true).Or("id IN ?", append(heldIDs(held), uuid.Nil)).
+		Order("sort_order ASC, created_at ASC").
+		Find(&achievements).Error; err != nil {
+		return nil, err
+	}
+
+	values := newMetricCache(s.db, userID)
+	resp := make([]dto.AchievementResponse, 0, len(achievements))
+	for _, a := range achievements {
+		item := dto.AchievementResponse{
+			Key:         a.Key,
+			Name:        a.Name,
+			Description: a.Description,
+			Icon:        a.Icon,
+			Threshold:   a.Threshold,
+		}
+		if ua, ok := heldByID[a.ID]; ok {
+			unlockedAt := ua.UnlockedAt
+			item.Unlocked = true
+			item.UnlockedAt = &unlockedAt
+			item.Progress = a.Threshold
+		} else {
+			value, err := values.get(a.Metric, a.Params)
+			if err != nil {
+				return nil, err
+			}
+			item.Progress = min(value, a.Threshold)
+		}
+		resp = append(resp, item)
+	}
+
+	return resp, nil
+}
+
+// --- Admin ---
+
+// ListAchievements returns every definition, including inactive ones (admin)
+func (s *AchievementService) ListAchievements() ([]models.Achievement, error) {
+	var achievements []models.Achievement
+	if err := s.db.Order("sort_order ASC, created_at ASC").Find(&achievements).Error; err != nil {
+		return nil, err
+	}
+	return achievements, nil
+}
+
+// CreateAchievement adds a badge built from an existing metric (admin)
+func (s *AchievementService) CreateAchievement(req *dto.CreateAchievementRequest) (*models.Achievement, error) {
+	if strings.TrimSpace(req.Key) == "" || strings.TrimSpace(req.Name) == "" {
+		return nil, errors.New("key and name are required")
+	}
+	if _, ok := achievementMetrics[req.Metric]; !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownMetric, req.Metric)
+	}
+	if req.Threshold < 1 {
+		return nil, errors.New("threshold must be at least 1")
+	}
+
+	achievement := achievementFromRequest(req)
+	if err := s.db.Create(&achievement).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, fmt.Errorf("achievement key %q already exists", achievement.Key)
+		}
+		return nil, fmt.Errorf("failed to create achievement: %w", err)
+	}
+	return &achievement, nil
+}
+
+// UpdateAchievement edits a badge definition. Key and metric are fixed once
+// created so existing unlocks keep their meaning. (admin)
+func (s *AchievementService) UpdateAchievement(id uuid.UUID, req *dto.UpdateAchievementRequest) (*models.Achievement, error) {
+	var achievement models.Achievement
+	if err := s.db.First(&achievement, "id = ?", id).Error; err != nil {
+		return nil, ErrAchievementNotFound
+	}
+
+	if req.Name != nil {
+		achievement.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Description != nil {
+		achievement.Description = *req.Description
+	}
+	if req.Icon != nil {
+		achievement.Icon = *req.Icon
+	}
+	if req.Params != nil {
+		achievement.Params = req.Params
+	}
+	if req.Threshold != nil {
+		if *req.Threshold < 1 {
+			return nil, errors.New("threshold must be at least 1")
+		}
+		achievement.Threshold = *req.Threshold
+	}
+	if req.SortOrder != nil {
+		achievement.SortOrder = *req.SortOrder
+	}
+	if req.Active != nil {
+		achievement.Active = *req.Active
+	}
+
+	if err := s.db.Save(&achievement).Error; err != nil {
+		return nil, err
+	}
+	return &achievement, nil
+}
+
+func (s *AchievementService) lockedAchievements(userID uuid.UUID) ([]models.Achievement, error) {
+	var achievements []models.Achievement
+	err := s.db.Where("active = ?", true).
+		Where("id NOT IN (?)", s.db.Model(&models.UserAchievement{}).Select("achievement_id").Where("user_id = ?", userID)).
+		Order("sort_order ASC").
+		Find(&achievements).Error
+	return achievements, err
+}
+
+func achievementFromRequest(req *dto.CreateAchievementRequest) models.Achievement {
+	return models.Achievement{
+		ID:          uuid.New(),
+		Key:         strings.TrimSpace(req.Key),
+		Name:        strings.TrimSpace(req.Name),
+		Description: req.Description,
+		Icon:        req.Icon,
+		Metric:      req.Metric,
+		Params:      req.Params,
+		Threshold:   req.Threshold,
+		SortOrder:   req.SortOrder,
+		Active:      req.Active,
+	}
+}
+
+func heldIDs(held []models.UserAchievement) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(held))
+	for _, ua := range held {
+		ids = append(ids, ua.AchievementID)
+	}
+	return ids
+}
+
+// metricCache computes each distinct metric/params pair once per evaluation
+type metricCache struct {
+	db     *gorm.DB
+	userID uuid.UUID
+	values map[string]int
+}
+
+func newMetricCache(db *gorm.DB, userID uuid.UUID) *metricCache {
+	return &metricCache{db: db, userID: userID, values: make(map[string]int)}
+}
+
+func (c *metricCache) get(metric string, params map[string]float64) (int, error) {
+	key := metric + fmt.Sprint(params) // fmt prints maps in key order
+	if v, ok := c.values[key]; ok {
+		return v, nil
+	}
+
+	compute, ok := achievementMetrics[metric]
+	if !ok {
+		// A definition that references a removed metric simply never unlocks
+		return 0, nil
+	}
+	v, err := compute(c.db, c.userID, params)
+	if err != nil {
+		return 0, fmt.Errorf("metric %s: %w", metric, err)
+	}
+	c.values[key] = v
+	return v, nil
+}
+
+// param reads a numeric rule parameter with a default
+func param(params map[string]float64, name string, def float64) float64 {
+	if v, ok := params[name]; ok {
+		return v
+	}
+	return def
+}
+
+// --- Metrics ---
+
+func metricTotalChecks(db *gorm.DB, userID uuid.UUID, _ map[string]float64) (int, error) {
+	var count int64
+	err := db.Model(&models.VibeCheck{}).Where("user_id = ?", userID).Count(&count).Error
+	return int(count), err
+}
+
+func metricCurrentStreak(db *gorm.DB, userID uuid.UUID, _ map[string]float64) (int, error) {
+	var streak models.VibeStreak
+	err := db.Where("user_id = ?", userID).Limit(1).Find(&streak).Error
+	return streak.CurrentStreak, err
+}
+
+func metricLongestStreak(db *gorm.DB, userID uuid.UUID, _ map[string]float64) (int, error) {
+	var streak models.VibeStreak
+	err := db.Where("user_id = ?", userID).Limit(1).Find(&streak).Error
+	return streak.LongestStreak, err
+}
+
+func metricDistinctAesthetics(db *gorm.DB, userID uuid.UUID, _ map[string]float64) (int, error) {
+	var count int64
+	err := db.Model(&models.VibeCheck{}).
+		Where("user_id = ? AND aesthetic != ''", userID).
+		Distinct("aesthetic").
+		Count(&count).Error
+	return int(count), err
+}
+
+// metricEarlyChecks counts check-ins made before params.before_hour in the
+// user's time zone (UTC when unset)
+func metricEarlyChecks(db *gorm.DB, userID uuid.UUID, params map[string]float64) (int, error) {
+	var count int64
+	err := db.Model(&models.VibeCheck{}).
+		Joins("LEFT JOIN user_settings ON user_settings.user_id = vibe_checks.user_id").
+		Where("vibe_checks.user_id = ?", userID).
+		Where("EXTRACT(HOUR FROM vibe_checks.created_at AT TIME ZONE COALESCE(user_settings.timezone, 'UTC')) < ?", int(param(params, "before_hour", 8))).
+		Count(&count).Error
+	return int(count), err
+}
+
+// metricHighScoreChecks counts check-ins scoring at least params.min_score
+func metricHighScoreChecks(db *gorm.DB, userID uuid.UUID, params map[string]float64) (int, error) {
+	var count int64
+	err := db.Model(&models.VibeCheck{}).
+		Where("user_id = ? AND vibe_score >= ?", userID, int(param(params, "min_score", 80))).
+		Count(&count).Error
+	return int(count), err
+}
+
+// metricComebacks counts check-ins scoring at least params.high_score whose
+// preceding params.days days averaged below params.low_avg, over at least
+// params.min_checks check-ins.
+func metricComebacks(db *gorm.DB, userID uuid.UUID, params map[string]float64) (int, error) {
+	var count int64
+	err := db.Raw(`
+		SELECT COUNT(*) FROM vibe_checks c
+		WHERE c.user_id = ? AND c.deleted_at IS NULL AND c.vibe_score >= ?
+		AND (
+			SELECT AVG(p.vibe_score) FROM vibe_checks p
+			WHERE p.user_id = c.user_id AND p.deleted_at IS NULL
+			AND p.check_date >= c.check_date - CAST(? AS integer) AND p.check_date < c.check_date
+			HAVING COUNT(*) >= ?
+		) < ?`,
+		userID,
+		int(param(params, "high_score", 70)),
+		int(param(params, "days", 7)),
+		int(param(params, "min_checks", 3)),
+		param(params, "low_avg", 40),
+	).Scan(&count).Error
+	return int(count), err
+}
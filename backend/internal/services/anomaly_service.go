@@ -0,0 +1,251 @@
+package services
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var ErrInsightNotFound = errors.New("insight not found")
+
+// Insight types
+const (
+	InsightSustainedDrop = "sustained_drop"
+	InsightVolatility    = "volatility"
+)
+
+// Baseline tracking and anomaly thresholds
+const (
+	baselineAlpha        = 0.05 // EWMA weight of each new check-in (~20 check memory)
+	baselineMinSamples   = 14   // Check-ins before anything is flagged
+	baselineSeedChecks   = 60   // History replayed when a baseline is first created
+	baselineMinStdDev    = 5.0  // Floor so very steady users aren't flagged for small dips
+	dropRunLength        = 3    // Consecutive low check-ins that make a sustained drop
+	dropRunMaxSpanDays   = 6    // ...all within this many days
+	dropMinSigmas        = 1.5  // How far below the mean each must be, in standard deviations
+	dropMinPoints        = 15.0 // ...and in absolute points
+	volatilityWindow     = 5    // Recent check-ins measured for swings
+	volatilityMultiplier = 2.5  // Swing size vs. the user's typical change
+	volatilityMinPoints  = 20.0 // Minimum average swing to count as unusual
+)
+
+// Nudge rate limits, so support doesn't turn into nagging
+const (
+	nudgeCooldown     = 72 * time.Hour     // Between any two nudges
+	nudgeTypeCooldown = 7 * 24 * time.Hour // Between nudges of the same type
+)
+
+var nudgeMessages = map[string]struct {
+	Title    string
+	Messages []string
+}{
+	InsightSustainedDrop: {
+		Title: "Rough few days?",
+		Messages: []string{
+			"Your vibe has been lower than usual lately. That's okay — be gentle with yourself, and maybe reach out to someone you trust.",
+			"The last few days seem heavier than your normal. A short walk, some water and a good night's sleep can make more difference than you'd think.",
+			"We noticed a dip in your vibe. Whatever's going on, you don't have to carry it alone — talking it through with a friend can help.",
+		},
+	},
+	InsightVolatility: {
+		Title: "Big ups and downs",
+		Messages: []string{
+			"Your vibe has been swinging more than usual. Small routines — regular sleep, meals, a few minutes outside — can help steady things.",
+			"Lots of highs and lows lately. Try jotting down what's behind the swings; patterns are easier to handle once you can see them.",
+		},
+	},
+}
+
+type AnomalyService struct {
+	db       *gorm.DB
+	settings *SettingsService
+}
+
+func NewAnomalyService(db *gorm.DB, settings *SettingsService) *AnomalyService {
+	return &AnomalyService{db: db, settings: settings}
+}
+
+// Observe updates the user's baseline with a new check-in and, when it
+// completes a sustained drop or unusually volatile stretch, records a nudge.
+// Returns the new insight, or nil when nothing was raised.
+func (s *AnomalyService) Observe(userID uuid.UUID, check *models.VibeCheck) (*models.Insight, error) {
+	baseline, err := s.loadBaseline(userID, check.ID)
+	if err != nil {
+		return nil, err
+	}
+	prior := *baseline
+
+	updateBaseline(baseline, float64(check.VibeScore))
+	if err := s.db.Save(baseline).Error; err != nil {
+		return nil, err
+	}
+
+	if prior.Samples < baselineMinSamples {
+		return nil, nil
+	}
+
+	var recent []models.VibeCheck
+	if err := s.db.Select("check_date", "vibe_score").
+		Where("user_id = ?", userID).
+		Order("check_date DESC").
+		Limit(volatilityWindow).
+		Find(&recent).Error; err != nil {
+		return nil, err
+	}
+
+	insightType := detectAnomaly(&prior, recent)
+	if insightType == "" {
+		return nil, nil
+	}
+	return s.nudge(userID, insightType, check)
+}
+
+// GetInsights returns the user's most recent insights, newest first
+func (s *AnomalyService) GetInsights(userID uuid.UUID, limit int) ([]models.Insight, int64, error) {
+	var insights []models.Insight
+	if err := s.db.Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&insights).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var unread int64
+	s.db.Model(&models.Insight{}).Where("user_id = ? AND read_at IS NULL", userID).Count(&unread)
+	return insights, unread, nil
+}
+
+// MarkInsightRead marks one of the user's insights as read
+func (s *AnomalyService) MarkInsightRead(userID, insightID uuid.UUID) error {
+	result := s.db.Model(&models.Insight{}).
+		Where("id = ? AND user_id = ? AND read_at IS NULL", insightID, userID).
+		Update("read_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		var count int64
+		s.db.Model(&models.Insight{}).Where("id = ? AND user_id = ?", insightID, userID).Count(&count)
+		if count == 0 {
+			return ErrInsightNotFound
+		}
+	}
+	return nil
+}
+
+// nudge records an insight unless the user opted out or was nudged recently
+func (s *AnomalyService) nudge(userID uuid.UUID, insightType string, check *models.VibeCheck) (*models.Insight, error) {
+	settings, err := s.settings.GetSettings(userID)
+	if err != nil {
+		return nil, err
+	}
+	if !settings.NudgesEnabled {
+		return nil, nil
+	}
+
+	now := time.Now()
+	var recent int64
+	if err := s.db.Model(&models.Insight{}).
+		Where("user_id = ? AND (created_at > ? OR (type = ? AND created_at > ?))",
+			userID, now.Add(-nudgeCooldown), insightType, now.Add(-nudgeTypeCooldown)).
+		Count(&recent).Error; err != nil {
+		return nil, err
+	}
+	if recent > 0 {
+		return nil, nil
+	}
+
+	content := nudgeMessages[insightType]
+	insight := models.Insight{
+		ID:          uuid.New(),
+		UserID:      userID,
+		Type:        insightType,
+		Title:       content.Title,
+		Message:     content.Messages[now.YearDay()%len(content.Messages)],
+		VibeCheckID: &check.ID,
+	}
+	if err := s.db.Create(&insight).Error; err != nil {
+		return nil, err
+	}
+	return &insight, nil
+}
+
+// loadBaseline returns the stored baseline, seeding it from history (excluding
+// the check being observed) the first time.
+func (s *AnomalyService) loadBaseline(userID, excludeCheckID uuid.UUID) (*models.VibeBaseline, error) {
+	var baseline models.VibeBaseline
+	err := s.db.Where("user_id = ?", userID).First(&baseline).Error
+	if err == nil {
+		return &baseline, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	var history []models.VibeCheck
+	if err := s.db.Select("vibe_score").
+		Where("user_id = ? AND id != ?", userID, excludeCheckID).
+		Order("check_date DESC").
+		Limit(baselineSeedChecks).
+		Find(&history).Error; err != nil {
+		return nil, err
+	}
+
+	baseline = models.VibeBaseline{ID: uuid.New(), UserID: userID}
+	for i := len(history) - 1; i >= 0; i-- {
+		updateBaseline(&baseline, float64(history[i].VibeScore))
+	}
+	return &baseline, nil
+}
+
+// updateBaseline folds a score into the EWMA mean, variance and volatility
+func updateBaseline(b *models.VibeBaseline, score float64) {
+	if b.Samples == 0 {
+		b.Mean, b.Variance, b.Volatility, b.LastScore, b.Samples = score, 0, 0, score, 1
+		return
+	}
+
+	diff := score - b.Mean
+	b.Mean += baselineAlpha * diff
+	b.Variance = (1 - baselineAlpha) * (b.Variance + baselineAlpha*diff*diff)
+	b.Volatility += baselineAlpha * (math.Abs(score-b.LastScore) - b.Volatility)
+	b.LastScore = score
+	b.Samples++
+}
+
+// detectAnomaly checks the most recent check-ins (newest first) against the
+// baseline as it stood before the latest one.
+func detectAnomaly(baseline *models.VibeBaseline, recent []models.VibeCheck) string {
+	sd := math.Max(math.Sqrt(baseline.Variance), baselineMinStdDev)
+
+	if len(recent) >= dropRunLength {
+		run := recent[:dropRunLength]
+		threshold := baseline.Mean - math.Max(dropMinSigmas*sd, dropMinPoints)
+		low := daysBetween(run[dropRunLength-1].CheckDate, run[0].CheckDate) <= dropRunMaxSpanDays
+		for _, c := range run {
+			if float64(c.VibeScore) >= threshold {
+				low = false
+			}
+		}
+		if low {
+			return InsightSustainedDrop
+		}
+	}
+
+	if len(recent) == volatilityWindow {
+		var swing float64
+		for i := 1; i < len(recent); i++ {
+			swing += math.Abs(float64(recent[i].VibeScore - recent[i-1].VibeScore))
+		}
+		swing /= float64(len(recent) - 1)
+		if swing >= math.Max(volatilityMultiplier*baseline.Volatility, volatilityMinPoints) {
+			return InsightVolatility
+		}
+	}
+
+	return ""
+}
@@ -9,6 +9,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"log"
 	"math/big"
 	"net/http"
 	"strings"
@@ -57,12 +58,13 @@ type AppleJWK struct {
 }
 
 type AuthService struct {
-	db  *gorm.DB
-	cfg *config.Config
+	db        *gorm.DB
+	cfg       *config.Config
+	referrals *ReferralService
 }
 
-func NewAuthService(db *gorm.DB, cfg *config.Config) *AuthService {
-	return &AuthService{db: db, cfg: cfg}
+func NewAuthService(db *gorm.DB, cfg *config.Config, referrals *ReferralService) *AuthService {
+	return &AuthService{db: db, cfg: cfg, referrals: referrals}
 }
 
 func (s *AuthService) Register(req *dto.RegisterRequest) (*dto.AuthResponse, error) {
@@ -89,6 +91,7 @@ func (s *AuthService) Register(req *dto.RegisterRequest) (*dto.AuthResponse, err
 	if err := s.db.Create(&user).Error; err != nil {
 		return nil, fmt.Errorf("failed to create user: %w", err)
 	}
+	s.recordReferral(&user, req.InviteCode, req.DeviceID, "email")
 
 	return s.generateTokenPair(&user)
 }
@@ -166,6 +169,58 @@ func (s *AuthService) DeleteAccount(userID uuid.UUID, password string) error {
 		// Remove blocks
 		tx.Where("blocker_id = ? OR blocked_id = ?", userID, userID).Delete(&models.Block{})
 
+		// Remove public share links
+		tx.Where("user_id = ?", userID).Delete(&models.ShareLink{})
+
+		// Remove unlocked achievements
+		tx.Where("user_id = ?", userID).Delete(&models.UserAchievement{})
+
+		// Remove streak freeze and repair history
+		tx.Where("user_id = ?", userID).Delete(&models.StreakEvent{})
+
+		// Remove Vibe Wrapped summaries
+		tx.Where("user_id = ?", userID).Delete(&models.VibeSummary{})
+
+		// Remove settings
+		tx.Where("user_id = ?", userID).Delete(&models.UserSettings{})
+
+		// Remove forecasts
+		tx.Where("user_id = ?", userID).Delete(&models.VibeForecast{})
+
+		// Remove baselines and insights
+		tx.Where("user_id = ?", userID).Delete(&models.VibeBaseline{})
+		tx.Where("user_id = ?", userID).Delete(&models.Insight{})
+		tx.Where("user_id = ?", userID).Delete(&models.MoodTerm{})
+
+		// Remove friendships, pending requests and invite code
+		tx.Where("user_id = ? OR friend_id = ?", userID, userID).Delete(&models.Friendship{})
+		tx.Where("sender_id = ? OR receiver_id = ?", userID, userID).Delete(&models.FriendRequest{})
+		tx.Where("user_id = ?", userID).Delete(&models.InviteCode{})
+
+		// Remove reactions and comments by the user or on their checks
+		ownChecks := tx.Model(&models.VibeCheck{}).Unscoped().Select("id").Where("user_id = ?", userID)
+		tx.Where("user_id = ? OR vibe_check_id IN (?)", userID, ownChecks).Delete(&models.Reaction{})
+		tx.Where("user_id = ? OR vibe_check_id IN (?)", userID, ownChecks).Delete(&models.Comment{})
+
+		// Remove micro-quiz answers given with the user's checks
+		tx.Where("vibe_check_id IN (?)", ownChecks).Delete(&models.QuizAnswer{})
+
+		// Remove discover entries and reactions by the user or on their entries
+		ownEntries := tx.Model(&models.DiscoverEntry{}).Select("id").Where("user_id = ?", userID)
+		tx.Where("user_id = ? OR entry_id IN (?)", userID, ownEntries).Delete(&models.DiscoverReaction{})
+		tx.Where("user_id = ?", userID).Delete(&models.DiscoverEntry{})
+
+		// Remove the profile, freeing the username
+		tx.Where("user_id = ?", userID).Delete(&models.Profile{})
+
+		// Remove past leaderboard standings
+		tx.Where("user_id = ?", userID).Delete(&models.LeaderboardResult{})
+
+		// Leave circles, handing owned ones to the next member
+		if err := leaveAllCircles(tx, userID); err != nil {
+			return err
+		}
+
 		// Soft-delete the user (GORM DeletedAt)
 		return tx.Delete(&user).Error
 	})
@@ -273,11 +328,23 @@ func (s *AuthService) AppleSignIn(req *dto.AppleSignInRequest) (*dto.AuthRespons
 		if err := s.db.Create(&user).Error; err != nil {
 			return nil, fmt.Errorf("failed to create Apple user: %w", err)
 		}
+		s.recordReferral(&user, req.InviteCode, req.DeviceID, "apple")
 	}
 
 	return s.generateTokenPair(&user)
 }
 
+// recordReferral credits the inviter of a new account. A failure here must
+// never fail sign-up, so it's only logged.
+func (s *AuthService) recordReferral(user *models.User, inviteCode, deviceID, source string) {
+	if inviteCode == "" {
+		return
+	}
+	if err := s.referrals.RecordSignup(user, inviteCode, deviceID, source); err != nil {
+		log.Printf("Referral signup failed for user %s: %v", user.ID, err)
+	}
+}
+
 func (s *AuthService) generateTokenPair(user *models.User) (*dto.AuthResponse, error) {
 	accessToken, err := s.generateAccessToken(user)
 	if err != nil {
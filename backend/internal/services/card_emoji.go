@@ -0,0 +1,255 @@
+package services
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"strings"
+)
+
+// The bundled Go fonts have no emoji glyphs, so cards draw the emoji of each
+// aesthetic (and the match card's default) from the vector art below. Shapes
+// are laid out on a unit square, y pointing down.
+
+// emojiShape reports whether a point of the unit square is inside the shape
+type emojiShape func(x, y float64) bool
+
+type emojiLayer struct {
+	color color.NRGBA
+	shape emojiShape
+}
+
+// emojiSamples is the supersampling grid per axis used for anti-aliasing
+const emojiSamples = 4
+
+var (
+	emojiSkin  = color.NRGBA{255, 204, 77, 255}
+	emojiInk   = color.NRGBA{102, 69, 0, 255}
+	emojiWhite = color.NRGBA{250, 250, 250, 255}
+)
+
+var emojiArt = map[string][]emojiLayer{
+	// 😌 Chill Vibes
+	"😌": {
+		{emojiSkin, disc(0.5, 0.5, 0.46)},
+		{color.NRGBA{255, 138, 128, 140}, union(ellipse(0.22, 0.6, 0.08, 0.05), ellipse(0.78, 0.6, 0.08, 0.05))},
+		{emojiInk, union(arc(0.33, 0.38, 0.09, 20, 160, 0.04), arc(0.67, 0.38, 0.09, 20, 160, 0.04))},
+		{emojiInk, arc(0.5, 0.56, 0.17, 30, 150, 0.045)},
+	},
+	// ⚡ High Energy
+	"⚡": {
+		{color.NRGBA{255, 210, 31, 255}, polygon(0.6, 0.02, 0.16, 0.56, 0.46, 0.56, 0.34, 0.98, 0.84, 0.4, 0.54, 0.4, 0.7, 0.02)},
+	},
+	// 💕 Hopeless Romantic
+	"💕": {
+		{color.NRGBA{244, 83, 138, 255}, heart(0.4, 0.58, 0.3)},
+		{color.NRGBA{255, 143, 184, 255}, heart(0.74, 0.26, 0.17)},
+	},
+	// 💞 Vibe Match default
+	"💞": {
+		{color.NRGBA{255, 92, 147, 255}, heart(0.36, 0.4, 0.24)},
+		{color.NRGBA{255, 158, 194, 255}, heart(0.66, 0.66, 0.2)},
+	},
+	// 🌧️ Melancholy Soul
+	"🌧": {
+		{color.NRGBA{207, 216, 220, 255}, union(disc(0.3, 0.46, 0.14), disc(0.5, 0.36, 0.2), disc(0.7, 0.46, 0.15), segment(0.3, 0.52, 0.7, 0.52, 0.24))},
+		{color.NRGBA{79, 163, 224, 255}, union(segment(0.32, 0.74, 0.27, 0.88, 0.05), segment(0.52, 0.74, 0.47, 0.92, 0.05), segment(0.72, 0.74, 0.67, 0.88, 0.05))},
+	},
+	// 🏔️ Adventure Mode
+	"🏔": {
+		{color.NRGBA{92, 145, 59, 255}, polygon(0.02, 0.84, 0.98, 0.84, 0.98, 0.94, 0.02, 0.94)},
+		{color.NRGBA{141, 155, 168, 255}, polygon(0.04, 0.86, 0.4, 0.2, 0.56, 0.46, 0.68, 0.32, 0.96, 0.86)},
+		{emojiWhite, union(
+			polygon(0.4, 0.2, 0.29, 0.4, 0.35, 0.37, 0.4, 0.43, 0.45, 0.36, 0.5, 0.38),
+			polygon(0.68, 0.32, 0.61, 0.43, 0.66, 0.41, 0.7, 0.45, 0.75, 0.44),
+		)},
+	},
+	// 🎨 Creative Flow
+	"🎨": {
+		{color.NRGBA{217, 158, 130, 255}, minus(ellipse(0.5, 0.52, 0.44, 0.38), disc(0.64, 0.7, 0.07))},
+		{color.NRGBA{221, 46, 68, 255}, disc(0.28, 0.44, 0.07)},
+		{emojiSkin, disc(0.42, 0.3, 0.07)},
+		{color.NRGBA{85, 172, 238, 255}, disc(0.6, 0.3, 0.07)},
+		{color.NRGBA{120, 177, 89, 255}, disc(0.76, 0.44, 0.07)},
+		{color.NRGBA{170, 141, 216, 255}, disc(0.34, 0.64, 0.07)},
+	},
+	// 🧘 Inner Peace
+	"🧘": {
+		{color.NRGBA{93, 95, 239, 255}, ellipse(0.5, 0.74, 0.38, 0.13)},
+		{color.NRGBA{146, 102, 204, 255}, polygon(0.37, 0.34, 0.63, 0.34, 0.67, 0.66, 0.33, 0.66)},
+		{emojiSkin, union(
+			disc(0.5, 0.2, 0.11),
+			segment(0.38, 0.38, 0.22, 0.64, 0.07),
+			segment(0.62, 0.38, 0.78, 0.64, 0.07),
+			disc(0.22, 0.66, 0.05), disc(0.78, 0.66, 0.05),
+		)},
+		{color.NRGBA{74, 55, 40, 255}, intersect(disc(0.5, 0.19, 0.115), above(0.16))},
+	},
+	// 👑 Main Character
+	"👑": {
+		{color.NRGBA{244, 197, 66, 255}, union(
+			polygon(0.1, 0.8, 0.1, 0.3, 0.3, 0.54, 0.5, 0.22, 0.7, 0.54, 0.9, 0.3, 0.9, 0.8),
+			disc(0.1, 0.28, 0.055), disc(0.5, 0.2, 0.055), disc(0.9, 0.28, 0.055),
+		)},
+		{color.NRGBA{224, 161, 0, 255}, polygon(0.1, 0.7, 0.9, 0.7, 0.9, 0.8, 0.1, 0.8)},
+		{color.NRGBA{221, 46, 68, 255}, disc(0.5, 0.56, 0.065)},
+		{color.NRGBA{85, 172, 238, 255}, union(disc(0.3, 0.64, 0.045), disc(0.7, 0.64, 0.045))},
+	},
+	// ☕ Cozy Era
+	"☕": {
+		{color.NRGBA{204, 214, 221, 255}, ellipse(0.46, 0.86, 0.38, 0.07)},
+		{emojiWhite, union(
+			minus(disc(0.76, 0.58, 0.12), disc(0.76, 0.58, 0.065)),
+			polygon(0.16, 0.42, 0.76, 0.42, 0.68, 0.84, 0.24, 0.84),
+		)},
+		{color.NRGBA{107, 62, 38, 255}, ellipse(0.46, 0.43, 0.29, 0.05)},
+		{color.NRGBA{255, 255, 255, 170}, union(
+			arc(0.38, 0.27, 0.05, -90, 90, 0.03), arc(0.38, 0.17, 0.05, 90, 270, 0.03),
+			arc(0.54, 0.27, 0.05, -90, 90, 0.03), arc(0.54, 0.17, 0.05, 90, 270, 0.03),
+		)},
+	},
+	// 🌙 Dark Academia
+	"🌙": {
+		{color.NRGBA{255, 217, 131, 255}, minus(disc(0.5, 0.5, 0.4), disc(0.68, 0.38, 0.34))},
+	},
+}
+
+// hasEmojiArt reports whether drawEmoji can paint the emoji
+func hasEmojiArt(emoji string) bool {
+	_, ok := emojiArt[emojiArtKey(emoji)]
+	return ok
+}
+
+// emojiArtKey drops variation selectors, which stored emoji may or may not carry
+func emojiArtKey(emoji string) string {
+	return strings.TrimSpace(strings.ReplaceAll(emoji, "\uFE0F", ""))
+}
+
+// drawEmoji paints the emoji's art into the size×size square at topLeft,
+// blending each layer over what's already there
+func drawEmoji(img *image.RGBA, emoji string, topLeft image.Point, size int) {
+	layers := emojiArt[emojiArtKey(emoji)]
+	step := 1 / float64(size*emojiSamples)
+	for py := 0; py < size; py++ {
+		for px := 0; px < size; px++ {
+			dst := img.RGBAAt(topLeft.X+px, topLeft.Y+py)
+			r, g, b := float64(dst.R), float64(dst.G), float64(dst.B)
+			for _, layer := range layers {
+				hits := 0
+				for sy := 0; sy < emojiSamples; sy++ {
+					for sx := 0; sx < emojiSamples; sx++ {
+						x := float64(px)/float64(size) + (float64(sx)+0.5)*step
+						y := float64(py)/float64(size) + (float64(sy)+0.5)*step
+						if layer.shape(x, y) {
+							hits++
+						}
+					}
+				}
+				if hits == 0 {
+					continue
+				}
+				a := float64(layer.color.A) / 255 * float64(hits) / (emojiSamples * emojiSamples)
+				r = r*(1-a) + float64(layer.color.R)*a
+				g = g*(1-a) + float64(layer.color.G)*a
+				b = b*(1-a) + float64(layer.color.B)*a
+			}
+			img.SetRGBA(topLeft.X+px, topLeft.Y+py, color.RGBA{uint8(r + 0.5), uint8(g + 0.5), uint8(b + 0.5), 255})
+		}
+	}
+}
+
+func disc(cx, cy, r float64) emojiShape {
+	return func(x, y float64) bool {
+		return (x-cx)*(x-cx)+(y-cy)*(y-cy) <= r*r
+	}
+}
+
+func ellipse(cx, cy, rx, ry float64) emojiShape {
+	return func(x, y float64) bool {
+		dx, dy := (x-cx)/rx, (y-cy)/ry
+		return dx*dx+dy*dy <= 1
+	}
+}
+
+// polygon takes x, y pairs; points inside by the even-odd rule
+func polygon(coords ...float64) emojiShape {
+	return func(x, y float64) bool {
+		inside := false
+		n := len(coords) / 2
+		for i, j := 0, n-1; i < n; j, i = i, i+1 {
+			xi, yi, xj, yj := coords[2*i], coords[2*i+1], coords[2*j], coords[2*j+1]
+			if (yi > y) != (yj > y) && x < (xj-xi)*(y-yi)/(yj-yi)+xi {
+				inside = !inside
+			}
+		}
+		return inside
+	}
+}
+
+// segment is a stroke of the given width with round caps
+func segment(x1, y1, x2, y2, width float64) emojiShape {
+	return func(x, y float64) bool {
+		dx, dy := x2-x1, y2-y1
+		t := math.Max(0, math.Min(1, ((x-x1)*dx+(y-y1)*dy)/(dx*dx+dy*dy)))
+		ex, ey := x-(x1+t*dx), y-(y1+t*dy)
+		return ex*ex+ey*ey <= width*width/4
+	}
+}
+
+// arc is a round-capped stroke along a circle from one angle to another, in
+// degrees clockwise from the positive x axis
+func arc(cx, cy, r, from, to, width float64) emojiShape {
+	rad := math.Pi / 180
+	caps := union(
+		disc(cx+r*math.Cos(from*rad), cy+r*math.Sin(from*rad), width/2),
+		disc(cx+r*math.Cos(to*rad), cy+r*math.Sin(to*rad), width/2),
+	)
+	sweep := math.Mod(to-from+360, 360)
+	return func(x, y float64) bool {
+		d := math.Hypot(x-cx, y-cy)
+		angle := math.Atan2(y-cy, x-cx) / rad
+		if math.Abs(d-r) <= width/2 && math.Mod(angle-from+720, 360) <= sweep {
+			return true
+		}
+		return caps(x, y)
+	}
+}
+
+// heart is the classic implicit heart curve centered on (cx, cy)
+func heart(cx, cy, size float64) emojiShape {
+	return func(x, y float64) bool {
+		hx, hy := (x-cx)/size, -(y-cy)/size+0.15
+		q := hx*hx + hy*hy - 1
+		return q*q*q-hx*hx*hy*hy*hy <= 0
+	}
+}
+
+// above is the half-plane over a horizontal line
+func above(lineY float64) emojiShape {
+	return func(_, y float64) bool {
+		return y < lineY
+	}
+}
+
+func union(shapes ...emojiShape) emojiShape {
+	return func(x, y float64) bool {
+		for _, s := range shapes {
+			if s(x, y) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func intersect(a, b emojiShape) emojiShape {
+	return func(x, y float64) bool {
+		return a(x, y) && b(x, y)
+	}
+}
+
+func minus(a, b emojiShape) emojiShape {
+	return func(x, y float64) bool {
+		return a(x, y) && !b(x, y)
+	}
+}
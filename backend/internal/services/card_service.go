@@ -0,0 +1,483 @@
+package services
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/dto"
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/models"
+	"github.com/google/uuid"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+)
+
+var ErrInvalidCardSize = errors.New("invalid card size: must be story or square")
+
+// Card sizes. Story matches Instagram/TikTok 9:16, square suits feeds and link previews.
+const (
+	CardSizeStory  = "story"
+	CardSizeSquare = "square"
+)
+
+var cardDimensions = map[string]image.Point{
+	CardSizeStory:  {1080, 1920},
+	CardSizeSquare: {1080, 1080},
+}
+
+// maxCachedCards bounds the in-memory render cache
+const maxCachedCards = 500
+
+// Bundled fonts (Go fonts are compiled into the binary, no filesystem lookup)
+var (
+	cardFontsOnce  sync.Once
+	cardFontsErr   error
+	cardFontBold   *sfnt.Font
+	cardFontNormal *sfnt.Font
+)
+
+func loadCardFonts() error {
+	cardFontsOnce.Do(func() {
+		if cardFontBold, cardFontsErr = opentype.Parse(gobold.TTF); cardFontsErr != nil {
+			return
+		}
+		cardFontNormal, cardFontsErr = opentype.Parse(goregular.TTF)
+	})
+	return cardFontsErr
+}
+
+type cachedCard struct {
+	png       []byte
+	updatedAt time.Time
+	cachedAt  time.Time
+}
+
+// CardService renders shareable vibe card PNGs server-side and caches the output.
+type CardService struct {
+	mu    sync.RWMutex
+	cache map[string]cachedCard
+}
+
+func NewCardService() *CardService {
+	return &CardService{cache: make(map[string]cachedCard)}
+}
+
+func cardCacheKey(id uuid.UUID, size string) string {
+	return id.String() + ":" + size
+}
+
+// RenderVibeCard returns the PNG for a vibe check. Cached renders are reused
+// until the check's UpdatedAt changes or the entry is invalidated.
+func (s *CardService) RenderVibeCard(check *models.VibeCheck, size string) ([]byte, error) {
+	dims, ok := cardDimensions[size]
+	if !ok {
+		return nil, ErrInvalidCardSize
+	}
+
+	key := cardCacheKey(check.ID, size)
+	s.mu.RLock()
+	cached, hit := s.cache[key]
+	s.mu.RUnlock()
+	if hit && cached.updatedAt.Equal(check.UpdatedAt) {
+		return cached.png, nil
+	}
+
+	data, err := renderCard(cardContent{
+		Title:          check.Aesthetic,
+		Emoji:          check.Emoji,
+		Score:          check.VibeScore,
+		ScoreLabel:     "VIBE SCORE",
+		Body:           check.Insight,
+		Footer:         check.CheckDate.Format("January 2, 2006"),
+		ColorPrimary:   check.ColorPrimary,
+		ColorSecondary: check.ColorSecondary,
+		ColorAccent:    check.ColorAccent,
+	}, dims)
+	if err != nil {
+		return nil, err
+	}
+
+	s.store(key, cachedCard{png: data, updatedAt: check.UpdatedAt, cachedAt: time.Now()})
+	return data, nil
+}
+
+// RenderSummaryCard returns the PNG for a Vibe Wrapped summary. Persisted
+// summaries never change, so they are cached by ID; live previews are not.
+func (s *CardService) RenderSummaryCard(summary *models.VibeSummary, size string) ([]byte, error) {
+	dims, ok := cardDimensions[size]
+	if !ok {
+		return nil, ErrInvalidCardSize
+	}
+
+	key := cardCacheKey(summary.ID, size)
+	if !summary.InProgress {
+		s.mu.RLock()
+		cached, hit := s.cache[key]
+		s.mu.RUnlock()
+		if hit {
+			return cached.png, nil
+		}
+	}
+
+	colors := aestheticByName(summary.DominantAesthetic)
+	body := "Mostly " + summary.DominantAesthetic
+	if summary.BestDay != nil {
+		body += fmt.Sprintf(" · Best day %s (%d)", formatCardDate(summary.BestDay.Date, "Mon Jan 2"), summary.BestDay.VibeScore)
+	}
+	if summary.LongestStreak > 1 {
+		body += fmt.Sprintf(" · %d-day streak", summary.LongestStreak)
+	}
+	if summary.PrevAvgScore != nil {
+		body += fmt.Sprintf(" · %+.0f vs last %s", summary.AvgScore-*summary.PrevAvgScore, summary.Period)
+	}
+	if len(summary.TopWords) > 0 {
+		words := make([]string, 0, len(summary.TopWords))
+		for _, w := range summary.TopWords {
+			words = append(words, w.Word)
+		}
+		body += " · " + strings.Join(words, ", ")
+	}
+
+	data, err := renderCard(cardContent{
+		Title:          summaryCardTitle(summary),
+		Emoji:          summary.DominantEmoji,
+		Score:          int(summary.AvgScore + 0.5),
+		ScoreLabel:     "AVG VIBE SCORE",
+		Body:           body,
+		Footer:         fmt.Sprintf("%d check-ins", summary.CheckCount),
+		ColorPrimary:   colors.ColorPrimary,
+		ColorSecondary: colors.ColorSecondary,
+		ColorAccent:    colors.ColorAccent,
+	}, dims)
+	if err != nil {
+		return nil, err
+	}
+
+	if !summary.InProgress {
+		s.store(key, cachedCard{png: data, updatedAt: summary.CreatedAt, cachedAt: time.Now()})
+	}
+	return data, nil
+}
+
+// RenderMatchCard renders a shareable vibe match card. Matches change daily,
+// so they are not cached.
+func (s *CardService) RenderMatchCard(match *dto.VibeMatch, size string) ([]byte, error) {
+	dims, ok := cardDimensions[size]
+	if !ok {
+		return nil, ErrInvalidCardSize
+	}
+
+	colors := aestheticByName(match.SharedAesthetic)
+	emoji := match.SharedEmoji
+	if emoji == "" {
+		emoji = "💞"
+	}
+	footer := fmt.Sprintf("%d days checked in together", match.OverlapDays)
+	if match.OverlapDays == 1 {
+		footer = "1 day checked in together"
+	}
+
+	return renderCard(cardContent{
+		Title:          "Vibe Match",
+		Emoji:          emoji,
+		Score:          match.Score,
+		ScoreLabel:     "COMPATIBILITY",
+		Body:           strings.Join(match.Explanation, " · "),
+		Footer:         footer,
+		ColorPrimary:   colors.ColorPrimary,
+		ColorSecondary: colors.ColorSecondary,
+		ColorAccent:    colors.ColorAccent,
+	}, dims)
+}
+
+// Invalidate drops every cached size for a vibe check. Every path that edits a
+// check calls it, so a stale render never outlives the edit even when UpdatedAt
+// doesn't move.
+func (s *CardService) Invalidate(checkID uuid.UUID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for size := range cardDimensions {
+		delete(s.cache, cardCacheKey(checkID, size))
+	}
+}
+
+func (s *CardService) store(key string, card cachedCard) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.cache[key]; !exists && len(s.cache) >= maxCachedCards {
+		// Evict the oldest render to stay within bounds
+		var oldestKey string
+		var oldest time.Time
+		for k, v := range s.cache {
+			if oldestKey == "" || v.cachedAt.Before(oldest) {
+				oldestKey, oldest = k, v.cachedAt
+			}
+		}
+		delete(s.cache, oldestKey)
+	}
+	s.cache[key] = card
+}
+
+func summaryCardTitle(summary *models.VibeSummary) string {
+	switch summary.Period {
+	case models.SummaryPeriodWeek:
+		return "Week of " + summary.PeriodStart.Format("Jan 2") + " Wrapped"
+	case models.SummaryPeriodMonth:
+		return summary.PeriodStart.Format("January 2006") + " Wrapped"
+	default:
+		return summary.PeriodStart.Format("2006") + " Wrapped"
+	}
+}
+
+// aestheticByName finds the preset colors for a stored aesthetic display name
+func aestheticByName(name string) (colors struct{ ColorPrimary, ColorSecondary, ColorAccent string }) {
+	for _, a := range models.Aesthetics {
+		if a.Name == name {
+			colors.ColorPrimary, colors.ColorSecondary, colors.ColorAccent = a.ColorPrimary, a.ColorSecondary, a.ColorAccent
+			return colors
+		}
+	}
+	return colors
+}
+
+func formatCardDate(date, layout string) string {
+	t, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return date
+	}
+	return t.Format(layout)
+}
+
+// cardContent is everything drawn on a card, independent of where it came from
+type cardContent struct {
+	Title          string
+	Emoji          string
+	Score          int
+	ScoreLabel     string
+	Body           string
+	Footer         string
+	ColorPrimary   string
+	ColorSecondary string
+	ColorAccent    string
+}
+
+// cardRow is one horizontally centered element of the card layout
+type cardRow struct {
+	face   font.Face
+	text   string
+	color  color.Color
+	height int
+	emoji  string // draw this emoji's built-in art instead of text
+	badge  bool   // draw an accent disc instead of text
+}
+
+// renderCard draws a diagonal gradient card with the emoji, title, score and body
+// text stacked and vertically centered, plus a footer along the bottom edge.
+func renderCard(content cardContent, dims image.Point) ([]byte, error) {
+	if err := loadCardFonts(); err != nil {
+		return nil, fmt.Errorf("failed to load card fonts: %w", err)
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, dims.X, dims.Y))
+	drawGradient(img, parseHexColor(content.ColorPrimary), parseHexColor(content.ColorSecondary))
+
+	scale := float64(dims.X) / 1080
+	margin := int(96 * scale)
+	maxWidth := dims.X - 2*margin
+	white := color.NRGBA{255, 255, 255, 255}
+	soft := color.NRGBA{255, 255, 255, 210}
+
+	// Square cards have roughly half the height, so the hero elements shrink
+	scoreSize, badgeSize, gap := 220*scale, 140*scale, int(48*scale)
+	if dims.Y <= dims.X {
+		scoreSize, badgeSize, gap = 150*scale, 96*scale, int(28*scale)
+	}
+
+	titleFace := newCardFace(cardFontBold, 88*scale)
+	scoreFace := newCardFace(cardFontBold, scoreSize)
+	labelFace := newCardFace(cardFontBold, 36*scale)
+	bodyFace := newCardFace(cardFontNormal, 46*scale)
+	footerFace := newCardFace(cardFontNormal, 34*scale)
+	emojiFace := newCardFace(cardFontBold, badgeSize)
+	defer func() {
+		for _, f := range []font.Face{titleFace, scoreFace, labelFace, bodyFace, footerFace, emojiFace} {
+			f.Close()
+		}
+	}()
+
+	var rows []cardRow
+
+	// Emoji art, then the font's glyph, then an accent badge when neither has it
+	if hasEmojiArt(content.Emoji) {
+		rows = append(rows, cardRow{emoji: content.Emoji, height: int(badgeSize) + gap})
+	} else if emoji := renderableText(cardFontBold, content.Emoji); emoji != "" {
+		rows = append(rows, cardRow{face: emojiFace, text: emoji, color: white, height: lineHeight(emojiFace) + gap})
+	} else {
+		rows = append(rows, cardRow{badge: true, height: int(badgeSize) + gap})
+	}
+	for _, line := range wrapText(titleFace, content.Title, maxWidth) {
+		rows = append(rows, cardRow{face: titleFace, text: line, color: white, height: lineHeight(titleFace)})
+	}
+	rows[len(rows)-1].height += gap
+	rows = append(rows,
+		cardRow{face: scoreFace, text: strconv.Itoa(content.Score), color: white, height: lineHeight(scoreFace)},
+		cardRow{face: labelFace, text: content.ScoreLabel, color: soft, height: lineHeight(labelFace) + gap},
+	)
+
+	// Body lines fill whatever room is left above the footer
+	available := dims.Y - 2*margin - lineHeight(footerFace) - gap
+	for _, r := range rows {
+		available -= r.height
+	}
+	bodyLine := lineHeight(bodyFace) * 5 / 4
+	for _, line := range wrapText(bodyFace, content.Body, maxWidth) {
+		if available < bodyLine {
+			break
+		}
+		rows = append(rows, cardRow{face: bodyFace, text: line, color: white, height: bodyLine})
+		available -= bodyLine
+	}
+
+	total := 0
+	for _, r := range rows {
+		total += r.height
+	}
+	y := (dims.Y - lineHeight(footerFace) - total) / 2
+	for _, r := range rows {
+		if r.emoji != "" {
+			drawEmoji(img, r.emoji, image.Pt((dims.X-int(badgeSize))/2, y), int(badgeSize))
+		} else if r.badge {
+			radius := int(badgeSize / 2)
+			drawBadge(img, image.Pt(dims.X/2, y+radius), radius, parseHexColor(content.ColorAccent))
+		} else {
+			drawCenteredText(img, r.face, r.text, y+r.face.Metrics().Ascent.Ceil(), r.color)
+		}
+		y += r.height
+	}
+
+	drawCenteredText(img, footerFace, content.Footer+"  ·  VibeCheck", dims.Y-margin, soft)
+
+	var buf bytes.Buffer
+	encoder := png.Encoder{CompressionLevel: png.BestSpeed}
+	if err := encoder.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("failed to encode card: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func newCardFace(f *sfnt.Font, size float64) font.Face {
+	face, err := opentype.NewFace(f, &opentype.FaceOptions{Size: size, DPI: 72, Hinting: font.HintingFull})
+	if err != nil {
+		// NewFace only fails on invalid options, which are fixed here
+		panic(err)
+	}
+	return face
+}
+
+func drawGradient(img *image.RGBA, from, to color.RGBA) {
+	b := img.Bounds()
+	span := float64(b.Dx() + b.Dy())
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			t := float64(x+y) / span
+			// Fade darker toward the bottom so white text stays readable on light gradients
+			shade := 1 - 0.25*float64(y)/float64(b.Dy())
+			img.SetRGBA(x, y, color.RGBA{
+				R: uint8(float64(lerp(from.R, to.R, t)) * shade),
+				G: uint8(float64(lerp(from.G, to.G, t)) * shade),
+				B: uint8(float64(lerp(from.B, to.B, t)) * shade),
+				A: 255,
+			})
+		}
+	}
+}
+
+func drawBadge(img *image.RGBA, center image.Point, radius int, c color.RGBA) {
+	r2 := radius * radius
+	for y := -radius; y <= radius; y++ {
+		for x := -radius; x <= radius; x++ {
+			if x*x+y*y <= r2 {
+				img.Set(center.X+x, center.Y+y, c)
+			}
+		}
+	}
+}
+
+func drawCenteredText(img *image.RGBA, face font.Face, text string, baseline int, c color.Color) {
+	width := font.MeasureString(face, text).Ceil()
+	x := (img.Bounds().Dx() - width) / 2
+
+	// Soft drop shadow first, then the text itself
+	shadow := &font.Drawer{Dst: img, Src: image.NewUniform(color.NRGBA{0, 0, 0, 60}), Face: face,
+		Dot: fixed.P(x+2, baseline+3)}
+	shadow.DrawString(text)
+	d := &font.Drawer{Dst: img, Src: image.NewUniform(c), Face: face, Dot: fixed.P(x, baseline)}
+	d.DrawString(text)
+}
+
+func lineHeight(face font.Face) int {
+	return face.Metrics().Height.Ceil()
+}
+
+// wrapText greedily breaks text into lines no wider than maxWidth pixels
+func wrapText(face font.Face, text string, maxWidth int) []string {
+	var lines []string
+	var current string
+	for _, word := range strings.Fields(text) {
+		candidate := word
+		if current != "" {
+			candidate = current + " " + word
+		}
+		if font.MeasureString(face, candidate).Ceil() <= maxWidth || current == "" {
+			current = candidate
+			continue
+		}
+		lines = append(lines, current)
+		current = word
+	}
+	if current != "" {
+		lines = append(lines, current)
+	}
+	return lines
+}
+
+// renderableText drops runes the font has no glyph for, so we never draw tofu boxes
+func renderableText(f *sfnt.Font, text string) string {
+	var buf sfnt.Buffer
+	var b strings.Builder
+	for _, r := range text {
+		if idx, err := f.GlyphIndex(&buf, r); err == nil && idx != 0 {
+			b.WriteRune(r)
+		}
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// parseHexColor parses "#rrggbb", falling back to a neutral slate
+func parseHexColor(hex string) color.RGBA {
+	hex = strings.TrimPrefix(hex, "#")
+	if len(hex) != 6 {
+		return color.RGBA{100, 116, 139, 255}
+	}
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return color.RGBA{100, 116, 139, 255}
+	}
+	return color.RGBA{R: uint8(v >> 16), G: uint8(v >> 8), B: uint8(v), A: 255}
+}
+
+func lerp(a, b uint8, t float64) uint8 {
+	return uint8(float64(a) + (float64(b)-float64(a))*t)
+}
@@ -0,0 +1,499 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/dto"
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrCircleNotFound      = errors.New("circle not found")
+	ErrCircleNameInvalid   = errors.New("circle name must be 1-50 characters")
+	ErrCircleNameRejected  = errors.New("circle name contains prohibited content")
+	ErrCircleFull          = errors.New("circle is full")
+	ErrCircleLimitReached  = errors.New("you're in the maximum number of circles")
+	ErrAlreadyCircleMember = errors.New("already a member of this circle")
+	ErrNotCircleOwner      = errors.New("only the circle owner can do this")
+	ErrCircleMemberMissing = errors.New("user is not a member of this circle")
+	ErrCannotKickSelf      = errors.New("use leave to exit your own circle")
+	// ErrCircleUnavailable hides whether a block is what stands in the way
+	ErrCircleUnavailable = errors.New("you can't join this circle")
+)
+
+// Circle limits
+const (
+	defaultCircleMembers = 12
+	maxCirclesPerUser    = 20
+	maxCircleNameLength  = 50
+)
+
+type CircleService struct {
+	db         *gorm.DB
+	moderation *ModerationService
+}
+
+func NewCircleService(db *gorm.DB, moderation *ModerationService) *CircleService {
+	return &CircleService{db: db, moderation: moderation}
+}
+
+// CreateCircle creates a circle with the user as its owner and first member
+func (s *CircleService) CreateCircle(userID uuid.UUID, req *dto.CreateCircleRequest) (*dto.CircleResponse, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" || utf8.RuneCountInString(name) > maxCircleNameLength {
+		return nil, ErrCircleNameInvalid
+	}
+	if clean, _ := s.moderation.FilterContent(name); !clean {
+		return nil, ErrCircleNameRejected
+	}
+
+	token, err := generateShareToken()
+	if err != nil {
+		return nil, err
+	}
+	circle := models.Circle{
+		ID:          uuid.New(),
+		OwnerID:     userID,
+		Name:        name,
+		Emoji:       req.Emoji,
+		InviteToken: token,
+		MaxMembers:  defaultCircleMembers,
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := checkCircleLimit(tx, userID); err != nil {
+			return err
+		}
+		if err := tx.Create(&circle).Error; err != nil {
+			return err
+		}
+		return tx.Create(&models.CircleMember{
+			ID:       uuid.New(),
+			CircleID: circle.ID,
+			UserID:   userID,
+			Role:     models.CircleRoleOwner,
+			JoinedAt: time.Now(),
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.GetCircle(userID, circle.ID)
+}
+
+// ListCircles returns the circles the user belongs to
+func (s *CircleService) ListCircles(userID uuid.UUID) ([]dto.CircleResponse, error) {
+	var circles []models.Circle
+	if err := s.db.Where("id IN (?)", s.db.Model(&models.CircleMember{}).Select("circle_id").Where("user_id = ?", userID)).
+		Order("created_at ASC").
+		Find(&circles).Error; err != nil {
+		return nil, err
+	}
+
+	ids := make([]uuid.UUID, len(circles))
+	for i, c := range circles {
+		ids[i] = c.ID
+	}
+	var counts []struct {
+		CircleID uuid.UUID
+		Count    int
+	}
+	if len(ids) > 0 {
+		if err := s.db.Model(&models.CircleMember{}).
+			Select("circle_id, COUNT(*) AS count").
+			Where("circle_id IN ?", ids).
+			Group("circle_id").
+			Scan(&counts).Error; err != nil {
+			return nil, err
+		}
+	}
+	memberCounts := make(map[uuid.UUID]int, len(counts))
+	for _, c := range counts {
+		memberCounts[c.CircleID] = c.Count
+	}
+
+	resp := make([]dto.CircleResponse, len(circles))
+	for i := range circles {
+		resp[i] = circleResponse(&circles[i], nil)
+		resp[i].MemberCount = memberCounts[circles[i].ID]
+	}
+	return resp, nil
+}
+
+// GetCircle returns a circle with its members, hiding anyone on either side of
+// a block with the viewer
+func (s *CircleService) GetCircle(userID, circleID uuid.UUID) (*dto.CircleResponse, error) {
+	circle, _, err := s.circleForMember(userID, circleID)
+	if err != nil {
+		return nil, err
+	}
+
+	var members []models.CircleMember
+	if err := s.db.Where("circle_id = ?", circleID).
+		Where(notBlockedEither("circle_members.user_id"), userID, userID).
+		Order("joined_at ASC").
+		Find(&members).Error; err != nil {
+		return nil, err
+	}
+
+	resp := circleResponse(circle, members)
+	ids := make([]uuid.UUID, len(members))
+	for i, m := range members {
+		ids[i] = m.UserID
+	}
+	summaries, err := profileSummaries(s.db, ids)
+	if err != nil {
+		return nil, err
+	}
+	for i := range resp.Members {
+		resp.Members[i].Profile = summaries[resp.Members[i].UserID]
+	}
+	return &resp, nil
+}
+
+// GetInvite returns the circle's invite token (owner only)
+func (s *CircleService) GetInvite(userID, circleID uuid.UUID) (string, error) {
+	circle, member, err := s.circleForMember(userID, circleID)
+	if err != nil {
+		return "", err
+	}
+	if member.Role != models.CircleRoleOwner {
+		return "", ErrNotCircleOwner
+	}
+	return circle.InviteToken, nil
+}
+
+// RotateInvite replaces the invite token, invalidating links already shared (owner only)
+func (s *CircleService) RotateInvite(userID, circleID uuid.UUID) (string, error) {
+	circle, member, err := s.circleForMember(userID, circleID)
+	if err != nil {
+		return "", err
+	}
+	if member.Role != models.CircleRoleOwner {
+		return "", ErrNotCircleOwner
+	}
+
+	token, err := generateShareToken()
+	if err != nil {
+		return "", err
+	}
+	if err := s.db.Model(circle).Update("invite_token", token).Error; err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// Join adds the user to the circle behind an invite token. Nobody can join a
+// circle containing someone they've blocked or who has blocked them.
+func (s *CircleService) Join(userID uuid.UUID, token string) (*dto.CircleResponse, error) {
+	var circle models.Circle
+	if err := s.db.Where("invite_token = ?", token).First(&circle).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCircleNotFound
+		}
+		return nil, err
+	}
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		// Lock the circle so concurrent joins can't overshoot the cap
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&circle, "id = ?", circle.ID).Error; err != nil {
+			return err
+		}
+
+		var members []models.CircleMember
+		if err := tx.Where("circle_id = ?", circle.ID).Find(&members).Error; err != nil {
+			return err
+		}
+		memberIDs := make([]uuid.UUID, len(members))
+		for i, m := range members {
+			if m.UserID == userID {
+				return ErrAlreadyCircleMember
+			}
+			memberIDs[i] = m.UserID
+		}
+		if len(members) >= circle.MaxMembers {
+			return ErrCircleFull
+		}
+		if err := checkCircleLimit(tx, userID); err != nil {
+			return err
+		}
+
+		var blocks int64
+		if err := tx.Model(&models.Block{}).
+			Where("(blocker_id = ? AND blocked_id IN ?) OR (blocked_id = ? AND blocker_id IN ?)", userID, memberIDs, userID, memberIDs).
+			Count(&blocks).Error; err != nil {
+			return err
+		}
+		if blocks > 0 {
+			return ErrCircleUnavailable
+		}
+
+		return tx.Create(&models.CircleMember{
+			ID:       uuid.New(),
+			CircleID: circle.ID,
+			UserID:   userID,
+			Role:     models.CircleRoleMember,
+			JoinedAt: time.Now(),
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.GetCircle(userID, circle.ID)
+}
+
+// Leave removes the user from a circle. An owner hands the circle to the
+// longest-standing member, or deletes it when nobody is left.
+func (s *CircleService) Leave(userID, circleID uuid.UUID) error {
+	circle, member, err := s.circleForMember(userID, circleID)
+	if err != nil {
+		return err
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(member).Error; err != nil {
+			return err
+		}
+		if member.Role != models.CircleRoleOwner {
+			return nil
+		}
+		return passCircleOwnership(tx, circle)
+	})
+}
+
+// passCircleOwnership hands a circle whose owner has left to its longest-standing
+// member, deleting the circle when nobody is left
+func passCircleOwnership(tx *gorm.DB, circle *models.Circle) error {
+	var successor models.CircleMember
+	err := tx.Where("circle_id = ?", circle.ID).Order("joined_at ASC").First(&successor).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return tx.Delete(circle).Error
+	}
+	if err != nil {
+		return err
+	}
+	if err := tx.Model(&successor).Update("role", models.CircleRoleOwner).Error; err != nil {
+		return err
+	}
+	return tx.Model(circle).Update("owner_id", successor.UserID).Error
+}
+
+// leaveAllCircles removes a user from every circle, passing on the ones they own.
+// Used when an account is deleted.
+func leaveAllCircles(tx *gorm.DB, userID uuid.UUID) error {
+	var owned []models.Circle
+	if err := tx.Where("owner_id = ?", userID).Find(&owned).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("user_id = ?", userID).Delete(&models.CircleMember{}).Error; err != nil {
+		return err
+	}
+	for i := range owned {
+		if err := passCircleOwnership(tx, &owned[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Kick removes another member (owner only)
+func (s *CircleService) Kick(userID, circleID, memberID uuid.UUID) error {
+	_, member, err := s.circleForMember(userID, circleID)
+	if err != nil {
+		return err
+	}
+	if member.Role != models.CircleRoleOwner {
+		return ErrNotCircleOwner
+	}
+	if memberID == userID {
+		return ErrCannotKickSelf
+	}
+
+	result := s.db.Where("circle_id = ? AND user_id = ?", circleID, memberID).Delete(&models.CircleMember{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrCircleMemberMissing
+	}
+	return nil
+}
+
+// DeleteCircle removes the circle and its memberships (owner only)
+func (s *CircleService) DeleteCircle(userID, circleID uuid.UUID) error {
+	circle, member, err := s.circleForMember(userID, circleID)
+	if err != nil {
+		return err
+	}
+	if member.Role != models.CircleRoleOwner {
+		return ErrNotCircleOwner
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("circle_id = ?", circleID).Delete(&models.CircleMember{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(circle).Error
+	})
+}
+
+// GetFeed returns members' check-ins, newest first, using the same cursor
+// format as the friends' feed
+func (s *CircleService) GetFeed(userID, circleID uuid.UUID, cursor string, limit int) (*dto.FeedResponse, error) {
+	if _, _, err := s.circleForMember(userID, circleID); err != nil {
+		return nil, err
+	}
+	if limit < 1 || limit > maxFeedLimit {
+		limit = defaultFeedLimit
+	}
+
+	query := s.memberChecks(userID, circleID).
+		Where("c.created_at >= ?", time.Now().AddDate(0, 0, -feedMaxAgeDays))
+	if cursor != "" {
+		createdAt, id, err := decodeFeedCursor(cursor)
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where("(c.created_at, c.id) < (?, ?)", createdAt, id)
+	}
+
+	var rows []models.VibeCheck
+	if err := query.Order("c.created_at DESC, c.id DESC").Limit(limit + 1).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	resp := &dto.FeedResponse{Data: make([]dto.FeedItem, 0, min(len(rows), limit))}
+	if len(rows) > limit {
+		rows = rows[:limit]
+		last := rows[limit-1]
+		resp.NextCursor = encodeFeedCursor(last.CreatedAt, last.ID)
+	}
+	for i := range rows {
+		resp.Data = append(resp.Data, feedItem(&rows[i], userID))
+	}
+	if err := attachFeedProfiles(s.db, resp.Data); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetToday returns the circle's mood board for today: average score, aesthetic
+// mix, and who has and hasn't checked in
+func (s *CircleService) GetToday(userID, circleID uuid.UUID) (*dto.CircleTodayResponse, error) {
+	if _, _, err := s.circleForMember(userID, circleID); err != nil {
+		return nil, err
+	}
+	today := time.Now().Truncate(24 * time.Hour)
+
+	var rows []models.VibeCheck
+	if err := s.memberChecks(userID, circleID).
+		Where("c.check_date = ?", today).
+		Order("c.created_at ASC").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	var memberIDs []uuid.UUID
+	if err := s.db.Model(&models.CircleMember{}).
+		Where("circle_id = ?", circleID).
+		Where(notBlockedEither("circle_members.user_id"), userID, userID).
+		Order("joined_at ASC").
+		Pluck("user_id", &memberIDs).Error; err != nil {
+		return nil, err
+	}
+
+	resp := &dto.CircleTodayResponse{
+		Date:       today.Format("2006-01-02"),
+		Aesthetics: make(map[string]int),
+		CheckedIn:  make([]dto.FeedItem, 0, len(rows)),
+		NotYet:     []uuid.UUID{},
+	}
+	checkedIn := make(map[uuid.UUID]bool, len(rows))
+	total := 0
+	for i := range rows {
+		resp.CheckedIn = append(resp.CheckedIn, feedItem(&rows[i], userID))
+		resp.Aesthetics[rows[i].Aesthetic]++
+		total += rows[i].VibeScore
+		checkedIn[*rows[i].UserID] = true
+	}
+	if len(rows) > 0 {
+		avg := roundTo(float64(total)/float64(len(rows)), 1)
+		resp.AvgScore = &avg
+	}
+	for _, id := range memberIDs {
+		if !checkedIn[id] {
+			resp.NotYet = append(resp.NotYet, id)
+		}
+	}
+	if err := attachFeedProfiles(s.db, resp.CheckedIn); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// memberChecks selects the check-ins members made since joining that the
+// viewer is allowed to read
+func (s *CircleService) memberChecks(viewerID, circleID uuid.UUID) *gorm.DB {
+	return s.db.Table("vibe_checks AS c").
+		Select("c.*").
+		Joins("JOIN circle_members cm ON cm.user_id = c.user_id AND cm.circle_id = ?", circleID).
+		Where("c.check_date >= CAST(cm.joined_at AS date)").
+		Where(readableBy("c", checkReader{UserID: viewerID}))
+}
+
+// circleForMember loads a circle and the user's membership; non-members get
+// ErrCircleNotFound so private circles can't be probed
+func (s *CircleService) circleForMember(userID, circleID uuid.UUID) (*models.Circle, *models.CircleMember, error) {
+	var member models.CircleMember
+	if err := s.db.Where("circle_id = ? AND user_id = ?", circleID, userID).First(&member).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ErrCircleNotFound
+		}
+		return nil, nil, err
+	}
+	var circle models.Circle
+	if err := s.db.Where("id = ?", circleID).First(&circle).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ErrCircleNotFound
+		}
+		return nil, nil, err
+	}
+	return &circle, &member, nil
+}
+
+func checkCircleLimit(tx *gorm.DB, userID uuid.UUID) error {
+	var count int64
+	if err := tx.Model(&models.CircleMember{}).Where("user_id = ?", userID).Count(&count).Error; err != nil {
+		return err
+	}
+	if count >= maxCirclesPerUser {
+		return ErrCircleLimitReached
+	}
+	return nil
+}
+
+func circleResponse(circle *models.Circle, members []models.CircleMember) dto.CircleResponse {
+	resp := dto.CircleResponse{
+		ID:          circle.ID,
+		Name:        circle.Name,
+		Emoji:       circle.Emoji,
+		OwnerID:     circle.OwnerID,
+		MaxMembers:  circle.MaxMembers,
+		MemberCount: len(members),
+		CreatedAt:   circle.CreatedAt.Format(time.RFC3339),
+	}
+	for _, m := range members {
+		resp.Members = append(resp.Members, dto.CircleMemberResponse{
+			UserID:   m.UserID,
+			Role:     m.Role,
+			JoinedAt: m.JoinedAt.Format(time.RFC3339),
+		})
+	}
+	return resp
+}
@@ -0,0 +1,240 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/dto"
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var ErrNotEnoughMatchData = errors.New("both of you need a few more check-ins for a vibe match")
+
+// Vibe match parameters
+const (
+	matchWindowDays        = 90
+	matchMinChecks         = 3 // Each side, within the window
+	matchMinOverlap        = 5 // Shared days before a score correlation is trusted
+	matchAestheticWeight   = 0.5
+	matchCorrelationWeight = 0.35
+	matchStreakWeight      = 0.15
+	matchStreakFull        = 7 // Shared streak that earns the full streak component
+)
+
+type CompatibilityService struct {
+	db         *gorm.DB
+	friends    *FriendService
+	moderation *ModerationService
+}
+
+func NewCompatibilityService(db *gorm.DB, friends *FriendService, moderation *ModerationService) *CompatibilityService {
+	return &CompatibilityService{db: db, friends: friends, moderation: moderation}
+}
+
+// GetMatch scores how well two friends' vibes line up over the recent window.
+// Only mutual friends with no block between them can be matched.
+func (s *CompatibilityService) GetMatch(userID, friendID uuid.UUID) (*dto.VibeMatch, error) {
+	friends, err := s.friends.AreFriends(userID, friendID)
+	if err != nil {
+		return nil, err
+	}
+	blocked, err := s.moderation.IsBlockedEither(userID, friendID)
+	if err != nil {
+		return nil, err
+	}
+	if !friends || blocked {
+		return nil, ErrNotFriends
+	}
+
+	today := time.Now().Truncate(24 * time.Hour)
+	from := today.AddDate(0, 0, -(matchWindowDays - 1))
+
+	// Each side only contributes checks the other side may read, so the score
+	// is symmetric and never draws on private check-ins
+	mine, err := s.windowChecks(userID, friendID, from, today)
+	if err != nil {
+		return nil, err
+	}
+	theirs, err := s.windowChecks(friendID, userID, from, today)
+	if err != nil {
+		return nil, err
+	}
+	if len(mine) < matchMinChecks || len(theirs) < matchMinChecks {
+		return nil, ErrNotEnoughMatchData
+	}
+
+	match := scoreMatch(mine, theirs, from, today)
+	match.FriendID = friendID
+	return match, nil
+}
+
+// scoreMatch compares two sides' checks by day over the window from..today
+func scoreMatch(mine, theirs map[string]models.VibeCheck, from, today time.Time) *dto.VibeMatch {
+	match := &dto.VibeMatch{WindowDays: matchWindowDays, Explanation: []string{}}
+
+	// Aesthetic mixes
+	myMix, theirMix := aestheticMix(mine), aestheticMix(theirs)
+	match.AestheticSimilarity = roundTo(cosineSimilarity(myMix, theirMix), 2)
+	best := 0.0
+	for aesthetic, share := range myMix {
+		if shared := math.Min(share, theirMix[aesthetic]); shared > best {
+			best, match.SharedAesthetic = shared, aesthetic
+		}
+	}
+	if match.SharedAesthetic != "" {
+		for _, c := range mine {
+			if c.Aesthetic == match.SharedAesthetic {
+				match.SharedEmoji = c.Emoji
+				break
+			}
+		}
+	}
+
+	// Score correlation and shared streaks on overlapping days
+	var xs, ys []float64
+	run := 0
+	for d := from; !d.After(today); d = d.AddDate(0, 0, 1) {
+		a, okA := mine[dayKey(d)]
+		b, okB := theirs[dayKey(d)]
+		if okA && okB {
+			xs = append(xs, float64(a.VibeScore))
+			ys = append(ys, float64(b.VibeScore))
+			run++
+			match.LongestSharedStreak = max(match.LongestSharedStreak, run)
+		} else if !d.Equal(today) {
+			run = 0 // Today doesn't break the streak until it's over
+		}
+	}
+	match.OverlapDays = len(xs)
+	match.SharedStreak = run
+	if len(xs) >= matchMinOverlap {
+		if r, ok := pearson(xs, ys); ok {
+			r = roundTo(r, 2)
+			match.ScoreCorrelation = &r
+		}
+	}
+
+	// Weighted score; the correlation's weight is shared out when it's unknown
+	streakPart := math.Min(float64(match.SharedStreak)/matchStreakFull, 1)
+	total := matchAestheticWeight*match.AestheticSimilarity + matchStreakWeight*streakPart
+	weights := matchAestheticWeight + matchStreakWeight
+	if match.ScoreCorrelation != nil {
+		total += matchCorrelationWeight * (*match.ScoreCorrelation + 1) / 2
+		weights += matchCorrelationWeight
+	}
+	match.Score = int(math.Round(clampScore(total / weights * 100)))
+
+	match.Explanation = matchExplanation(match)
+	return match
+}
+
+// windowChecks returns the owner's checks in the window that the reader may read, by day
+func (s *CompatibilityService) windowChecks(ownerID, readerID uuid.UUID, from, to time.Time) (map[string]models.VibeCheck, error) {
+	var checks []models.VibeCheck
+	if err := s.db.Table("vibe_checks AS c").
+		Select("c.user_id", "c.check_date", "c.vibe_score", "c.aesthetic", "c.emoji").
+		Where("c.user_id = ? AND c.check_date >= ? AND c.check_date <= ?", ownerID, from, to).
+		Where(readableBy("c", checkReader{UserID: readerID})).
+		Find(&checks).Error; err != nil {
+		return nil, err
+	}
+
+	days := make(map[string]models.VibeCheck, len(checks))
+	for _, c := range checks {
+		days[dayKey(c.CheckDate)] = c
+	}
+	return days, nil
+}
+
+// dayKey identifies a calendar day. Dates scanned from Postgres come back in
+// UTC while the window is built in local time, and time.Time map keys compare
+// locations too, so days are keyed by their date string.
+func dayKey(t time.Time) string {
+	return t.Format("2006-01-02")
+}
+
+// aestheticMix returns each aesthetic's share of the days
+func aestheticMix(days map[string]models.VibeCheck) map[string]float64 {
+	mix := make(map[string]float64)
+	for _, c := range days {
+		mix[c.Aesthetic] += 1 / float64(len(days))
+	}
+	return mix
+}
+
+func cosineSimilarity(a, b map[string]float64) float64 {
+	var dot, normA, normB float64
+	for k, v := range a {
+		dot += v * b[k]
+		normA += v * v
+	}
+	for _, v := range b {
+		normB += v * v
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
+
+// pearson returns the correlation of two equal-length series; false when
+// either is constant.
+func pearson(xs, ys []float64) (float64, bool) {
+	n := float64(len(xs))
+	var meanX, meanY float64
+	for i := range xs {
+		meanX += xs[i]
+		meanY += ys[i]
+	}
+	meanX /= n
+	meanY /= n
+
+	var cov, varX, varY float64
+	for i := range xs {
+		dx, dy := xs[i]-meanX, ys[i]-meanY
+		cov += dx * dy
+		varX += dx * dx
+		varY += dy * dy
+	}
+	if varX == 0 || varY == 0 {
+		return 0, false
+	}
+	return cov / math.Sqrt(varX*varY), true
+}
+
+// matchExplanation turns the components into short, display-ready reasons
+func matchExplanation(match *dto.VibeMatch) []string {
+	var lines []string
+	switch {
+	case match.AestheticSimilarity >= 0.8:
+		lines = append(lines, fmt.Sprintf("You share almost the same vibe mix, mostly %s", match.SharedAesthetic))
+	case match.AestheticSimilarity >= 0.5 && match.SharedAesthetic != "":
+		lines = append(lines, fmt.Sprintf("You both often feel %s", match.SharedAesthetic))
+	default:
+		lines = append(lines, "Your vibes run in different directions — opposites attract")
+	}
+
+	if r := match.ScoreCorrelation; r != nil {
+		switch {
+		case *r >= 0.5:
+			lines = append(lines, fmt.Sprintf("Your good and bad days line up across %d shared days", match.OverlapDays))
+		case *r <= -0.3:
+			lines = append(lines, "When one of you is down, the other tends to be up")
+		default:
+			lines = append(lines, "Your daily ups and downs are mostly independent")
+		}
+	} else {
+		lines = append(lines, fmt.Sprintf("Check in on the same days to compare ups and downs (%d shared so far)", match.OverlapDays))
+	}
+
+	if match.SharedStreak > 1 {
+		lines = append(lines, fmt.Sprintf("You've both checked in %d days in a row", match.SharedStreak))
+	} else if match.LongestSharedStreak > 1 {
+		lines = append(lines, fmt.Sprintf("Your best shared streak is %d days", match.LongestSharedStreak))
+	}
+	return lines
+}
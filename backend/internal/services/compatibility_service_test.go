@@ -0,0 +1,115 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/models"
+)
+
+// matchDays builds one side's window checks the way windowChecks returns
+// them: dates come back from Postgres as UTC midnights, keyed by day. Scores
+// are indexed by days before today; a negative score means no check that day.
+func matchDays(today time.Time, scores ...int) map[string]models.VibeCheck {
+	days := make(map[string]models.VibeCheck)
+	for ago, score := range scores {
+		if score < 0 {
+			continue
+		}
+		y, m, d := today.AddDate(0, 0, -ago).Date()
+		date := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+		days[dayKey(date)] = models.VibeCheck{CheckDate: date, VibeScore: score, Aesthetic: "Chill Vibes", Emoji: "😌"}
+	}
+	return days
+}
+
+func TestScoreMatch(t *testing.T) {
+	// Built in local time like GetMatch, so the window never shares a
+	// location with the scanned dates
+	today := time.Now().Truncate(24 * time.Hour)
+	from := today.AddDate(0, 0, -(matchWindowDays - 1))
+
+	tests := []struct {
+		name          string
+		mine, theirs  []int // By days before today; -1 is a missed day
+		overlap       int
+		sharedStreak  int
+		longestStreak int
+		correlation   *float64
+	}{
+		{
+			name:          "every day together, moving in step",
+			mine:          []int{80, 74, 68, 62, 56, 50, 44},
+			theirs:        []int{70, 64, 58, 52, 46, 40, 34},
+			overlap:       7,
+			sharedStreak:  7,
+			longestStreak: 7,
+			correlation:   ptr(1.0),
+		},
+		{
+			name:          "moving in opposite directions",
+			mine:          []int{80, 70, 60, 50, 40},
+			theirs:        []int{40, 50, 60, 70, 80},
+			overlap:       5,
+			sharedStreak:  5,
+			longestStreak: 5,
+			correlation:   ptr(-1.0),
+		},
+		{
+			name:          "a missed day breaks the shared streak",
+			mine:          []int{60, 61, 70, 62, 75, 64, 50, 66, 67},
+			theirs:        []int{58, 59, -1, 60, 73, 62, 48, 64, 65},
+			overlap:       8,
+			sharedStreak:  2,
+			longestStreak: 6,
+			correlation:   ptr(1.0),
+		},
+		{
+			name:          "today doesn't break the streak before it's over",
+			mine:          []int{-1, 60, 65, 70},
+			theirs:        []int{55, 58, 63, 69},
+			overlap:       3,
+			sharedStreak:  3,
+			longestStreak: 3,
+		},
+		{
+			name:          "too few shared days for a correlation",
+			mine:          []int{60, 70, -1, 80, 90},
+			theirs:        []int{-1, 65, 75, 85, 95},
+			overlap:       3,
+			sharedStreak:  1,
+			longestStreak: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			match := scoreMatch(matchDays(today, tt.mine...), matchDays(today, tt.theirs...), from, today)
+
+			if match.OverlapDays != tt.overlap {
+				t.Errorf("OverlapDays = %d, want %d", match.OverlapDays, tt.overlap)
+			}
+			if match.SharedStreak != tt.sharedStreak {
+				t.Errorf("SharedStreak = %d, want %d", match.SharedStreak, tt.sharedStreak)
+			}
+			if match.LongestSharedStreak != tt.longestStreak {
+				t.Errorf("LongestSharedStreak = %d, want %d", match.LongestSharedStreak, tt.longestStreak)
+			}
+			switch {
+			case tt.correlation == nil && match.ScoreCorrelation != nil:
+				t.Errorf("ScoreCorrelation = %v, want nil", *match.ScoreCorrelation)
+			case tt.correlation != nil && match.ScoreCorrelation == nil:
+				t.Errorf("ScoreCorrelation = nil, want %v", *tt.correlation)
+			case tt.correlation != nil && *match.ScoreCorrelation != *tt.correlation:
+				t.Errorf("ScoreCorrelation = %v, want %v", *match.ScoreCorrelation, *tt.correlation)
+			}
+			if match.AestheticSimilarity != 1 {
+				t.Errorf("AestheticSimilarity = %v, want 1", match.AestheticSimilarity)
+			}
+		})
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,288 @@
+package services
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/dto"
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrDiscoverEntryNotFound = errors.New("discover entry not found")
+	ErrUnknownAesthetic      = errors.New("unknown aesthetic")
+)
+
+// Discover moderation thresholds. The stream is seen by strangers, so it is
+// screened harder than content shared with friends.
+const (
+	discoverMinAccountAge   = 24 * time.Hour      // Brand-new accounts can't publish
+	discoverStrikeWindow    = 30 * 24 * time.Hour // An actioned discover report keeps the author out this long
+	discoverHideThreshold   = 3                   // Distinct pending reporters that auto-hide an entry
+	discoverMaxMoodTextSize = 280
+)
+
+// discoverTextPatterns catch personal details and links, which would break
+// the stream's anonymity or invite spam. Text matching any of them is dropped.
+var discoverTextPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)[a-z0-9._%+-]+@[a-z0-9.-]+\.[a-z]{2,}`),                  // Email addresses
+	regexp.MustCompile(`(?i)\b(https?://|www\.)\S+|\b\S+\.(com|net|org|io|me|co)\b`), // Links
+	regexp.MustCompile(`(^|\s)@\w{2,}`),                                              // Handles
+	regexp.MustCompile(`(\d[\s().-]*){7,}`),                                          // Phone numbers
+}
+
+type DiscoverService struct {
+	db         *gorm.DB
+	moderation *ModerationService
+	settings   *SettingsService
+}
+
+func NewDiscoverService(db *gorm.DB, moderation *ModerationService, settings *SettingsService) *DiscoverService {
+	return &DiscoverService{db: db, moderation: moderation, settings: settings}
+}
+
+// Publish screens a new check for the discover stream when its owner has
+// opted in. The entry only shows while the check is public and the owner stays
+// opted in; its mood text only shows when it passed screening and isn't hidden.
+func (s *DiscoverService) Publish(check *models.VibeCheck) error {
+	if check.UserID == nil {
+		return nil
+	}
+	settings, err := s.settings.GetSettings(*check.UserID)
+	if err != nil {
+		return err
+	}
+	if !settings.DiscoverOptIn {
+		return nil
+	}
+
+	status := models.DiscoverStatusApproved
+	allowed, err := s.authorAllowed(*check.UserID)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		status = models.DiscoverStatusRejected
+	}
+
+	entry := models.DiscoverEntry{
+		ID:          uuid.New(),
+		VibeCheckID: check.ID,
+		UserID:      *check.UserID,
+		MoodText:    s.screenText(check.MoodText),
+		Status:      status,
+	}
+	return s.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&entry).Error
+}
+
+// GetStream returns approved entries newest first, optionally for one
+// aesthetic (by key like "chill" or name like "Chill Vibes")
+func (s *DiscoverService) GetStream(viewerID uuid.UUID, aesthetic, cursor string, limit int) (*dto.DiscoverResponse, error) {
+	if limit < 1 || limit > maxFeedLimit {
+		limit = defaultFeedLimit
+	}
+
+	query := s.visibleEntries(viewerID).
+		Where("d.created_at >= ?", time.Now().AddDate(0, 0, -feedMaxAgeDays))
+	if aesthetic != "" {
+		name, err := aestheticName(aesthetic)
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where("c.aesthetic = ?", name)
+	}
+	if cursor != "" {
+		createdAt, id, err := decodeFeedCursor(cursor)
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where("(d.created_at, d.id) < (?, ?)", createdAt, id)
+	}
+
+	var rows []discoverRow
+	if err := query.Order("d.created_at DESC, d.id DESC").Limit(limit + 1).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	resp := &dto.DiscoverResponse{Data: make([]dto.DiscoverEntry, 0, min(len(rows), limit))}
+	if len(rows) > limit {
+		rows = rows[:limit]
+		last := rows[limit-1]
+		resp.NextCursor = encodeFeedCursor(last.CreatedAt, last.ID)
+	}
+	for i := range rows {
+		resp.Data = append(resp.Data, discoverEntry(&rows[i]))
+	}
+	if err := s.fillReactions(viewerID, resp.Data); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// SetReaction adds or replaces the viewer's reaction on an entry
+func (s *DiscoverService) SetReaction(viewerID, entryID uuid.UUID, emoji string) error {
+	if !ReactionEmojis[emoji] {
+		return ErrInvalidReaction
+	}
+	if err := s.ensureVisible(viewerID, entryID); err != nil {
+		return err
+	}
+
+	reaction := models.DiscoverReaction{ID: uuid.New(), EntryID: entryID, UserID: viewerID, Emoji: emoji}
+	return s.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "entry_id"}, {Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"emoji", "updated_at"}),
+	}).Create(&reaction).Error
+}
+
+// RemoveReaction clears the viewer's reaction on an entry
+func (s *DiscoverService) RemoveReaction(viewerID, entryID uuid.UUID) error {
+	return s.db.Where("entry_id = ? AND user_id = ?", entryID, viewerID).Delete(&models.DiscoverReaction{}).Error
+}
+
+// discoverRow is an entry joined with the check it shows
+type discoverRow struct {
+	ID           uuid.UUID
+	MoodText     string
+	CreatedAt    time.Time
+	Aesthetic    string
+	Emoji        string
+	ColorPrimary string
+	VibeScore    int
+	CheckDate    time.Time
+	HideMoodText bool
+}
+
+// visibleEntries selects the approved entries of opted-in users whose checks
+// are public and readable by the viewer
+func (s *DiscoverService) visibleEntries(viewerID uuid.UUID) *gorm.DB {
+	return s.db.Table("discover_entries AS d").
+		Select("d.id, d.mood_text, d.created_at, c.aesthetic, c.emoji, c.color_primary, c.vibe_score, c.check_date, c.hide_mood_text").
+		Joins("JOIN vibe_checks c ON c.id = d.vibe_check_id").
+		Joins("JOIN user_settings st ON st.user_id = d.user_id AND st.discover_opt_in").
+		Where("d.status = ? AND c.visibility = ?", models.DiscoverStatusApproved, models.VisibilityPublic).
+		Where(readableBy("c", checkReader{UserID: viewerID}))
+}
+
+func (s *DiscoverService) ensureVisible(viewerID, entryID uuid.UUID) error {
+	var ids []uuid.UUID
+	if err := s.visibleEntries(viewerID).Where("d.id = ?", entryID).Pluck("d.id", &ids).Error; err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return ErrDiscoverEntryNotFound
+	}
+	return nil
+}
+
+// fillReactions adds reaction counts and the viewer's own reaction to entries
+func (s *DiscoverService) fillReactions(viewerID uuid.UUID, entries []dto.DiscoverEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	ids := make([]uuid.UUID, len(entries))
+	for i := range entries {
+		ids[i] = entries[i].ID
+	}
+
+	var reactions []models.DiscoverReaction
+	if err := s.db.Select("entry_id", "user_id", "emoji").Where("entry_id IN ?", ids).Find(&reactions).Error; err != nil {
+		return err
+	}
+	byEntry := make(map[uuid.UUID]int, len(entries))
+	for i := range entries {
+		byEntry[entries[i].ID] = i
+	}
+	for _, r := range reactions {
+		entry := &entries[byEntry[r.EntryID]]
+		entry.Reactions[r.Emoji]++
+		if r.UserID == viewerID {
+			entry.MyReaction = r.Emoji
+		}
+	}
+	return nil
+}
+
+// authorAllowed keeps out new accounts, banned users, widely blocked users and
+// anyone whose discover entry was taken down recently
+func (s *DiscoverService) authorAllowed(userID uuid.UUID) (bool, error) {
+	var user models.User
+	if err := s.db.Select("id", "created_at").Where("id = ?", userID).First(&user).Error; err != nil {
+		return false, err
+	}
+	if time.Since(user.CreatedAt) < discoverMinAccountAge {
+		return false, nil
+	}
+
+	var strikes int64
+	if err := s.db.Model(&models.Report{}).
+		Where("status = ?", "actioned").
+		Where("(content_type = 'user' AND content_id = ?) OR (content_type = 'discover' AND updated_at >= ? AND content_id IN (?))",
+			userID.String(), time.Now().Add(-discoverStrikeWindow),
+			s.db.Model(&models.DiscoverEntry{}).Select("id::text").Where("user_id = ?", userID)).
+		Count(&strikes).Error; err != nil {
+		return false, err
+	}
+	if strikes > 0 {
+		return false, nil
+	}
+
+	var blockers int64
+	if err := s.db.Model(&models.Block{}).Where("blocked_id = ?", userID).Distinct("blocker_id").Count(&blockers).Error; err != nil {
+		return false, err
+	}
+	return blockers < globalBlockThreshold, nil
+}
+
+// screenText returns the mood text if it passes the stricter discover
+// screening, or "" so the entry shows without words
+func (s *DiscoverService) screenText(text string) string {
+	text = strings.TrimSpace(text)
+	if text == "" || len([]rune(text)) > discoverMaxMoodTextSize {
+		return ""
+	}
+	if clean, _ := s.moderation.FilterContent(text); !clean {
+		return ""
+	}
+	for _, pattern := range discoverTextPatterns {
+		if pattern.MatchString(text) {
+			return ""
+		}
+	}
+	return text
+}
+
+func discoverEntry(row *discoverRow) dto.DiscoverEntry {
+	entry := dto.DiscoverEntry{
+		ID:           row.ID,
+		Aesthetic:    row.Aesthetic,
+		Emoji:        row.Emoji,
+		ColorPrimary: row.ColorPrimary,
+		VibeScore:    row.VibeScore,
+		Date:         row.CheckDate.Format("2006-01-02"),
+		Reactions:    make(map[string]int),
+	}
+	if row.MoodText != "" && !row.HideMoodText {
+		moodText := row.MoodText
+		entry.MoodText = &moodText
+	}
+	return entry
+}
+
+// aestheticName resolves an aesthetic key or display name to the stored name
+func aestheticName(aesthetic string) (string, error) {
+	if preset, ok := models.Aesthetics[strings.ToLower(aesthetic)]; ok {
+		return preset.Name, nil
+	}
+	for _, preset := range models.Aesthetics {
+		if strings.EqualFold(preset.Name, aesthetic) {
+			return preset.Name, nil
+		}
+	}
+	return "", ErrUnknownAesthetic
+}
@@ -0,0 +1,167 @@
+package services
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/dto"
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var ErrInvalidFeedCursor = errors.New("invalid cursor")
+
+const (
+	defaultFeedLimit = 20
+	maxFeedLimit     = 50
+	feedMaxAgeDays   = 30 // Older check-ins drop out of the feed
+)
+
+type FeedService struct {
+	db *gorm.DB
+}
+
+func NewFeedService(db *gorm.DB) *FeedService {
+	return &FeedService{db: db}
+}
+
+// GetFeed returns the user's friends' check-ins, newest first. Pages are
+// keyed on (created_at, id) so new check-ins never shift later pages.
+func (s *FeedService) GetFeed(userID uuid.UUID, cursor string, limit int) (*dto.FeedResponse, error) {
+	if limit < 1 || limit > maxFeedLimit {
+		limit = defaultFeedLimit
+	}
+
+	query := s.friendChecks(userID).
+		Where("c.created_at >= ?", time.Now().AddDate(0, 0, -feedMaxAgeDays))
+	if cursor != "" {
+		createdAt, id, err := decodeFeedCursor(cursor)
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where("(c.created_at, c.id) < (?, ?)", createdAt, id)
+	}
+
+	var rows []models.VibeCheck
+	if err := query.Order("c.created_at DESC, c.id DESC").Limit(limit + 1).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	resp := &dto.FeedResponse{Data: make([]dto.FeedItem, 0, min(len(rows), limit))}
+	if len(rows) > limit {
+		rows = rows[:limit]
+		last := rows[limit-1]
+		resp.NextCursor = encodeFeedCursor(last.CreatedAt, last.ID)
+	}
+	for i := range rows {
+		resp.Data = append(resp.Data, feedItem(&rows[i], userID))
+	}
+	if err := attachFeedProfiles(s.db, resp.Data); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetToday returns today's check-ins from friends and which friends haven't checked in yet
+func (s *FeedService) GetToday(userID uuid.UUID) (*dto.FeedTodayResponse, error) {
+	today := time.Now().Truncate(24 * time.Hour)
+
+	var rows []models.VibeCheck
+	if err := s.friendChecks(userID).
+		Where("c.check_date = ?", today).
+		Order("c.created_at DESC").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	var friendIDs []uuid.UUID
+	if err := s.db.Model(&models.Friendship{}).
+		Where("user_id = ?", userID).
+		Where(notBlockedEither("friendships.friend_id"), userID, userID).
+		Order("created_at ASC").
+		Pluck("friend_id", &friendIDs).Error; err != nil {
+		return nil, err
+	}
+
+	resp := &dto.FeedTodayResponse{
+		Date:       today.Format("2006-01-02"),
+		CheckedIn:  make([]dto.FeedItem, 0, len(rows)),
+		NotYet:     []uuid.UUID{},
+		TotalCount: len(friendIDs),
+	}
+	checkedIn := make(map[uuid.UUID]bool, len(rows))
+	for i := range rows {
+		resp.CheckedIn = append(resp.CheckedIn, feedItem(&rows[i], userID))
+		checkedIn[*rows[i].UserID] = true
+	}
+	for _, id := range friendIDs {
+		if !checkedIn[id] {
+			resp.NotYet = append(resp.NotYet, id)
+		}
+	}
+	if err := attachFeedProfiles(s.db, resp.CheckedIn); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// friendChecks selects the check-ins of the user's friends that the user is
+// allowed to read, in one query
+func (s *FeedService) friendChecks(userID uuid.UUID) *gorm.DB {
+	return s.db.Table("vibe_checks AS c").
+		Select("c.*").
+		Joins("JOIN friendships fr ON fr.friend_id = c.user_id AND fr.user_id = ?", userID).
+		Where(readableBy("c", checkReader{UserID: userID}))
+}
+
+// notBlockedEither is a condition excluding rows whose user column is on either
+// side of a block with the viewer; it takes the viewer's ID twice.
+func notBlockedEither(column string) string {
+	return "NOT EXISTS (SELECT 1 FROM blocks b WHERE (b.blocker_id = ? AND b.blocked_id = " + column + ") OR (b.blocker_id = " + column + " AND b.blocked_id = ?))"
+}
+
+// feedItem shows a check to a reader, with its mood text only when allowed
+func feedItem(row *models.VibeCheck, readerID uuid.UUID) dto.FeedItem {
+	item := dto.FeedItem{
+		CheckID:      row.ID,
+		UserID:       *row.UserID,
+		Aesthetic:    row.Aesthetic,
+		Emoji:        row.Emoji,
+		ColorPrimary: row.ColorPrimary,
+		VibeScore:    row.VibeScore,
+		CheckDate:    row.CheckDate.Format("2006-01-02"),
+		CreatedAt:    row.CreatedAt.Format(time.RFC3339),
+	}
+	if moodTextReadable(row, readerID) {
+		moodText := row.MoodText
+		item.MoodText = &moodText
+	}
+	return item
+}
+
+func encodeFeedCursor(createdAt time.Time, id uuid.UUID) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(createdAt.UTC().Format(time.RFC3339Nano) + "|" + id.String()))
+}
+
+func decodeFeedCursor(cursor string) (time.Time, uuid.UUID, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return time.Time{}, uuid.Nil, ErrInvalidFeedCursor
+	}
+	ts, idStr, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return time.Time{}, uuid.Nil, ErrInvalidFeedCursor
+	}
+	createdAt, err := time.Parse(time.RFC3339Nano, ts)
+	if err != nil {
+		return time.Time{}, uuid.Nil, ErrInvalidFeedCursor
+	}
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		return time.Time{}, uuid.Nil, ErrInvalidFeedCursor
+	}
+	return createdAt, id, nil
+}
@@ -0,0 +1,229 @@
+package services
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/dto"
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var ErrNotEnoughHistory = errors.New("not enough check-ins to forecast yet")
+
+// Forecast model parameters
+const (
+	forecastModel         = "holt_weekday_v1"
+	forecastMinChecks     = 5
+	forecastHistoryDays   = 120
+	forecastAlpha         = 0.3  // Level smoothing
+	forecastBeta          = 0.1  // Momentum (trend) smoothing
+	forecastTrendDamping  = 0.8  // Momentum carried into the forecast step
+	forecastSeasonalPrior = 3.0  // Pseudo-checks shrinking weekday effects toward zero
+	forecastBandZ         = 1.28 // 80% band under a normal error
+	forecastMinBand       = 5.0  // Never claim more precision than this
+	forecastRecencyDecay  = 0.93 // Per-day weight decay for aesthetic likelihood
+	forecastAccuracyLimit = 30   // Resolved forecasts included in a user's accuracy
+)
+
+type ForecastService struct {
+	db *gorm.DB
+}
+
+func NewForecastService(db *gorm.DB) *ForecastService {
+	return &ForecastService{db: db}
+}
+
+// GetTomorrowForecast returns the forecast for the day after today, creating it
+// on first request. Forecasts are fixed once made so they can be scored fairly.
+func (s *ForecastService) GetTomorrowForecast(userID uuid.UUID) (*models.VibeForecast, error) {
+	target := time.Now().Truncate(24*time.Hour).AddDate(0, 0, 1)
+
+	var existing models.VibeForecast
+	err := s.db.Where("user_id = ? AND target_date = ?", userID, target).First(&existing).Error
+	if err == nil {
+		return &existing, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	var checks []models.VibeCheck
+	if err := s.db.Select("check_date", "vibe_score", "aesthetic").
+		Where("user_id = ? AND check_date >= ? AND check_date < ?", userID, target.AddDate(0, 0, -forecastHistoryDays), target).
+		Order("check_date ASC").
+		Find(&checks).Error; err != nil {
+		return nil, err
+	}
+	if len(checks) < forecastMinChecks {
+		return nil, ErrNotEnoughHistory
+	}
+
+	forecast := forecastFromHistory(checks, target)
+	forecast.ID = uuid.New()
+	forecast.UserID = userID
+
+	if err := s.db.Clauses(clause.OnConflict{DoNothing: true}).Create(forecast).Error; err != nil {
+		return nil, err
+	}
+	if err := s.db.Where("user_id = ? AND target_date = ?", userID, target).First(&existing).Error; err != nil {
+		return nil, err
+	}
+	return &existing, nil
+}
+
+// Resolve scores the forecast for a check's day against what actually happened.
+// Days without a forecast are ignored.
+func (s *ForecastService) Resolve(userID uuid.UUID, check *models.VibeCheck) error {
+	var forecast models.VibeForecast
+	err := s.db.Where("user_id = ? AND target_date = ? AND resolved_at IS NULL", userID, check.CheckDate).
+		First(&forecast).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	actual := check.VibeScore
+	absError := roundTo(math.Abs(float64(actual)-forecast.PredictedScore), 1)
+	withinBand := float64(actual) >= forecast.Lower && float64(actual) <= forecast.Upper
+	hit := check.Aesthetic == forecast.PredictedAesthetic
+	now := time.Now()
+
+	return s.db.Model(&forecast).Updates(map[string]interface{}{
+		"actual_score":     actual,
+		"actual_aesthetic": check.Aesthetic,
+		"abs_error":        absError,
+		"within_band":      withinBand,
+		"aesthetic_hit":    hit,
+		"resolved_at":      now,
+	}).Error
+}
+
+// GetAccuracy summarizes the user's most recent resolved forecasts, or every
+// user's when userID is nil (admin).
+func (s *ForecastService) GetAccuracy(userID *uuid.UUID) (*dto.ForecastAccuracy, error) {
+	query := s.db.Model(&models.VibeForecast{}).Where("resolved_at IS NOT NULL")
+	if userID != nil {
+		query = query.Where("user_id = ?", *userID).Order("target_date DESC").Limit(forecastAccuracyLimit)
+	}
+
+	var resolved []models.VibeForecast
+	if err := query.Select("abs_error", "within_band", "aesthetic_hit").Find(&resolved).Error; err != nil {
+		return nil, err
+	}
+
+	accuracy := &dto.ForecastAccuracy{Resolved: len(resolved)}
+	if len(resolved) == 0 {
+		return accuracy, nil
+	}
+	var errSum float64
+	var inBand, hits int
+	for _, f := range resolved {
+		if f.AbsError != nil {
+			errSum += *f.AbsError
+		}
+		if f.WithinBand != nil && *f.WithinBand {
+			inBand++
+		}
+		if f.AestheticHit != nil && *f.AestheticHit {
+			hits++
+		}
+	}
+	n := float64(len(resolved))
+	accuracy.MeanAbsError = roundTo(errSum/n, 1)
+	accuracy.WithinBandRate = roundTo(float64(inBand)/n, 2)
+	accuracy.AestheticHitRate = roundTo(float64(hits)/n, 2)
+	return accuracy, nil
+}
+
+// forecastFromHistory runs Holt's linear smoothing (level plus damped momentum)
+// over the score series, adds a shrunken weekday effect for the target day, and
+// sizes the band from the one-step-ahead errors seen in history.
+func forecastFromHistory(checks []models.VibeCheck, target time.Time) *models.VibeForecast {
+	mean := 0.0
+	for _, c := range checks {
+		mean += float64(c.VibeScore)
+	}
+	mean /= float64(len(checks))
+
+	// Weekday effects: average deviation from the mean, shrunk for small samples
+	var weekdaySum [7]float64
+	var weekdayCount [7]int
+	for _, c := range checks {
+		wd := c.CheckDate.Weekday()
+		weekdaySum[wd] += float64(c.VibeScore) - mean
+		weekdayCount[wd]++
+	}
+	var seasonal [7]float64
+	for wd := range seasonal {
+		if weekdayCount[wd] > 0 {
+			seasonal[wd] = weekdaySum[wd] / (float64(weekdayCount[wd]) + forecastSeasonalPrior)
+		}
+	}
+
+	// Holt smoothing on deseasonalized scores, collecting one-step errors
+	level := float64(checks[0].VibeScore) - seasonal[checks[0].CheckDate.Weekday()]
+	trend := 0.0
+	var sqErr float64
+	for _, c := range checks[1:] {
+		season := seasonal[c.CheckDate.Weekday()]
+		predicted := level + forecastTrendDamping*trend + season
+		residual := float64(c.VibeScore) - predicted
+		sqErr += residual * residual
+
+		prevLevel := level
+		level = forecastAlpha*(float64(c.VibeScore)-season) + (1-forecastAlpha)*(level+forecastTrendDamping*trend)
+		trend = forecastBeta*(level-prevLevel) + (1-forecastBeta)*forecastTrendDamping*trend
+	}
+	rmse := math.Sqrt(sqErr / float64(len(checks)-1))
+
+	predicted := clampScore(level + forecastTrendDamping*trend + seasonal[target.Weekday()])
+	band := math.Max(forecastBandZ*rmse, forecastMinBand)
+
+	aesthetic, chance := likelyAesthetic(checks, target)
+
+	return &models.VibeForecast{
+		TargetDate:         target,
+		Model:              forecastModel,
+		PredictedScore:     roundTo(predicted, 1),
+		Lower:              roundTo(clampScore(predicted-band), 1),
+		Upper:              roundTo(clampScore(predicted+band), 1),
+		PredictedAesthetic: aesthetic,
+		AestheticChance:    roundTo(chance, 2),
+	}
+}
+
+// likelyAesthetic weights past aesthetics by recency, doubling those seen on
+// the target's weekday, and returns the favorite with its share of the weight.
+func likelyAesthetic(checks []models.VibeCheck, target time.Time) (string, float64) {
+	weights := make(map[string]float64)
+	var total float64
+	for _, c := range checks {
+		w := math.Pow(forecastRecencyDecay, float64(daysBetween(c.CheckDate, target)))
+		if c.CheckDate.Weekday() == target.Weekday() {
+			w *= 2
+		}
+		weights[c.Aesthetic] += w
+		total += w
+	}
+
+	best, bestWeight := "", 0.0
+	for aesthetic, w := range weights {
+		if w > bestWeight || (w == bestWeight && aesthetic < best) {
+			best, bestWeight = aesthetic, w
+		}
+	}
+	if total == 0 {
+		return best, 0
+	}
+	return best, bestWeight / total
+}
+
+func clampScore(v float64) float64 {
+	return math.Max(0, math.Min(100, v))
+}
@@ -0,0 +1,455 @@
+package services
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/dto"
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrFriendUserNotFound is also returned when a block stands between the
+	// two users, so a block is never revealed to the blocked side.
+	ErrFriendUserNotFound      = errors.New("user not found")
+	ErrSelfFriend              = errors.New("cannot send a friend request to yourself")
+	ErrAlreadyFriends          = errors.New("already friends")
+	ErrFriendRequestExists     = errors.New("friend request already sent")
+	ErrFriendRequestNotFound   = errors.New("friend request not found")
+	ErrNotFriends              = errors.New("not friends with this user")
+	ErrFriendLimitReached      = errors.New("friend limit reached")
+	ErrTooManyPendingRequests  = errors.New("too many pending friend requests")
+	ErrFriendRequestTargetless = errors.New("user_id, username or code is required")
+)
+
+// Friend graph limits
+const (
+	maxFriends              = 1000
+	maxPendingOutgoing      = 100
+	inviteCodeLength        = 8
+	inviteCodeAlphabet      = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789" // No 0/O or 1/I lookalikes
+	inviteCodeCreateRetries = 5
+)
+
+// Friend lookup statuses
+const (
+	FriendStatusNone     = "none"
+	FriendStatusFriends  = "friends"
+	FriendStatusIncoming = "incoming"
+	FriendStatusOutgoing = "outgoing"
+	FriendStatusSelf     = "self"
+)
+
+type FriendService struct {
+	db         *gorm.DB
+	moderation *ModerationService
+}
+
+func NewFriendService(db *gorm.DB, moderation *ModerationService) *FriendService {
+	return &FriendService{db: db, moderation: moderation}
+}
+
+// SendRequest sends a friend request to the user identified by ID, username
+// or invite code. If that user already asked the sender, the two become friends instead.
+// Returns the friendship status after the call ("outgoing" or "friends").
+func (s *FriendService) SendRequest(senderID uuid.UUID, req *dto.SendFriendRequest) (string, error) {
+	var receiverID uuid.UUID
+	switch {
+	case req.UserID != nil:
+		receiverID = *req.UserID
+	case req.Username != "":
+		id, err := s.userIDByUsername(req.Username)
+		if err != nil {
+			return "", err
+		}
+		receiverID = id
+	case req.Code != "":
+		id, err := s.userIDByCode(req.Code)
+		if err != nil {
+			return "", err
+		}
+		receiverID = id
+	default:
+		return "", ErrFriendRequestTargetless
+	}
+
+	if receiverID == senderID {
+		return "", ErrSelfFriend
+	}
+	if err := s.ensureReachable(senderID, receiverID); err != nil {
+		return "", err
+	}
+
+	status := FriendStatusOutgoing
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		current, err := friendStatus(tx, senderID, receiverID)
+		if err != nil {
+			return err
+		}
+		switch current {
+		case FriendStatusFriends:
+			return ErrAlreadyFriends
+		case FriendStatusOutgoing:
+			return ErrFriendRequestExists
+		case FriendStatusIncoming:
+			status = FriendStatusFriends
+			return acceptBetween(tx, receiverID, senderID)
+		}
+
+		var pending int64
+		if err := tx.Model(&models.FriendRequest{}).Where("sender_id = ?", senderID).Count(&pending).Error; err != nil {
+			return err
+		}
+		if pending >= maxPendingOutgoing {
+			return ErrTooManyPendingRequests
+		}
+		if err := checkFriendLimit(tx, senderID); err != nil {
+			return err
+		}
+
+		request := models.FriendRequest{ID: uuid.New(), SenderID: senderID, ReceiverID: receiverID}
+		if err := tx.Create(&request).Error; err != nil {
+			if errors.Is(err, gorm.ErrDuplicatedKey) {
+				return ErrFriendRequestExists
+			}
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return status, nil
+}
+
+// AcceptRequest accepts an incoming request, making both users friends
+func (s *FriendService) AcceptRequest(userID, requestID uuid.UUID) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		var request models.FriendRequest
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND receiver_id = ?", requestID, userID).
+			First(&request).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrFriendRequestNotFound
+			}
+			return err
+		}
+
+		// A block placed after the request was sent voids it
+		blocked, err := s.moderation.IsBlockedEither(request.SenderID, userID)
+		if err != nil {
+			return err
+		}
+		if blocked {
+			if err := tx.Delete(&request).Error; err != nil {
+				return err
+			}
+			return ErrFriendRequestNotFound
+		}
+
+		return acceptBetween(tx, request.SenderID, userID)
+	})
+}
+
+// DeclineRequest removes an incoming request without telling the sender
+func (s *FriendService) DeclineRequest(userID, requestID uuid.UUID) error {
+	return s.deleteRequest("id = ? AND receiver_id = ?", requestID, userID)
+}
+
+// CancelRequest withdraws a request the user sent
+func (s *FriendService) CancelRequest(userID, requestID uuid.UUID) error {
+	return s.deleteRequest("id = ? AND sender_id = ?", requestID, userID)
+}
+
+func (s *FriendService) deleteRequest(query string, requestID, userID uuid.UUID) error {
+	result := s.db.Where(query, requestID, userID).Delete(&models.FriendRequest{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrFriendRequestNotFound
+	}
+	return nil
+}
+
+// RemoveFriend ends a friendship in both directions
+func (s *FriendService) RemoveFriend(userID, friendID uuid.UUID) error {
+	result := s.db.Where("(user_id = ? AND friend_id = ?) OR (user_id = ? AND friend_id = ?)", userID, friendID, friendID, userID).
+		Delete(&models.Friendship{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFriends
+	}
+	return nil
+}
+
+// ListFriends returns the user's friends, most recent first
+func (s *FriendService) ListFriends(userID uuid.UUID) ([]dto.FriendResponse, error) {
+	blockedIDs, err := s.moderation.GetBlockedIDs(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	query := s.db.Where("user_id = ?", userID)
+	if len(blockedIDs) > 0 {
+		query = query.Where("friend_id NOT IN ?", blockedIDs)
+	}
+	var friendships []models.Friendship
+	if err := query.Order("created_at DESC").Find(&friendships).Error; err != nil {
+		return nil, err
+	}
+
+	ids := make([]uuid.UUID, len(friendships))
+	for i, f := range friendships {
+		ids[i] = f.FriendID
+	}
+	summaries, err := profileSummaries(s.db, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	friends := make([]dto.FriendResponse, len(friendships))
+	for i, f := range friendships {
+		friends[i] = dto.FriendResponse{UserID: f.FriendID, Profile: summaries[f.FriendID], Since: f.CreatedAt.Format(time.RFC3339)}
+	}
+	return friends, nil
+}
+
+// ListRequests returns the user's pending incoming and outgoing requests
+func (s *FriendService) ListRequests(userID uuid.UUID) (*dto.FriendRequestsResponse, error) {
+	var requests []models.FriendRequest
+	if err := s.db.Where("sender_id = ? OR receiver_id = ?", userID, userID).
+		Order("created_at DESC").
+		Find(&requests).Error; err != nil {
+		return nil, err
+	}
+
+	blockedIDs, err := s.moderation.GetBlockedIDs(userID)
+	if err != nil {
+		return nil, err
+	}
+	blocked := make(map[uuid.UUID]bool, len(blockedIDs))
+	for _, id := range blockedIDs {
+		blocked[id] = true
+	}
+	ids := make([]uuid.UUID, 0, len(requests))
+	for _, r := range requests {
+		ids = append(ids, r.SenderID, r.ReceiverID)
+	}
+	summaries, err := profileSummaries(s.db, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.FriendRequestsResponse{
+		Incoming: []dto.FriendRequestResponse{},
+		Outgoing: []dto.FriendRequestResponse{},
+	}
+	for _, r := range requests {
+		item := dto.FriendRequestResponse{ID: r.ID, CreatedAt: r.CreatedAt.Format(time.RFC3339)}
+		if r.SenderID == userID {
+			item.UserID, item.Direction = r.ReceiverID, FriendStatusOutgoing
+		} else {
+			item.UserID, item.Direction = r.SenderID, FriendStatusIncoming
+		}
+		if blocked[item.UserID] {
+			continue
+		}
+		item.Profile = summaries[item.UserID]
+		if item.Direction == FriendStatusOutgoing {
+			resp.Outgoing = append(resp.Outgoing, item)
+		} else {
+			resp.Incoming = append(resp.Incoming, item)
+		}
+	}
+	return resp, nil
+}
+
+// Lookup finds a user by username or invite code and reports how they relate to the caller
+func (s *FriendService) Lookup(userID uuid.UUID, username, code string) (*dto.FriendLookupResponse, error) {
+	var targetID uuid.UUID
+	var err error
+	if username != "" {
+		targetID, err = s.userIDByUsername(username)
+	} else {
+		targetID, err = s.userIDByCode(code)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	status := FriendStatusSelf
+	if targetID != userID {
+		if err := s.ensureReachable(userID, targetID); err != nil {
+			return nil, err
+		}
+		if status, err = friendStatus(s.db, userID, targetID); err != nil {
+			return nil, err
+		}
+	}
+
+	summaries, err := profileSummaries(s.db, []uuid.UUID{targetID})
+	if err != nil {
+		return nil, err
+	}
+	return &dto.FriendLookupResponse{UserID: targetID, Profile: summaries[targetID], Status: status}, nil
+}
+
+// GetInviteCode returns the user's invite code, creating one on first use
+func (s *FriendService) GetInviteCode(userID uuid.UUID) (*models.InviteCode, error) {
+	var invite models.InviteCode
+	err := s.db.Where("user_id = ?", userID).First(&invite).Error
+	if err == nil {
+		return &invite, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	for attempt := 0; attempt < inviteCodeCreateRetries; attempt++ {
+		code, err := generateInviteCode()
+		if err != nil {
+			return nil, err
+		}
+		invite = models.InviteCode{ID: uuid.New(), UserID: userID, Code: code}
+		err = s.db.Create(&invite).Error
+		if err == nil {
+			return &invite, nil
+		}
+		if !errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, err
+		}
+		// Either the code collided or a concurrent request created the user's code
+		var existing models.InviteCode
+		if s.db.Where("user_id = ?", userID).First(&existing).Error == nil {
+			return &existing, nil
+		}
+	}
+	return nil, errors.New("failed to generate a unique invite code")
+}
+
+// AreFriends reports whether the two users are friends
+func (s *FriendService) AreFriends(userID, otherID uuid.UUID) (bool, error) {
+	var count int64
+	err := s.db.Model(&models.Friendship{}).Where("user_id = ? AND friend_id = ?", userID, otherID).Count(&count).Error
+	return count > 0, err
+}
+
+// ensureReachable hides deleted users and anyone on either side of a block
+func (s *FriendService) ensureReachable(userID, targetID uuid.UUID) error {
+	var count int64
+	if err := s.db.Model(&models.User{}).Where("id = ?", targetID).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return ErrFriendUserNotFound
+	}
+	blocked, err := s.moderation.IsBlockedEither(userID, targetID)
+	if err != nil {
+		return err
+	}
+	if blocked {
+		return ErrFriendUserNotFound
+	}
+	return nil
+}
+
+func (s *FriendService) userIDByUsername(username string) (uuid.UUID, error) {
+	id, err := usernameOwner(s.db, username)
+	if errors.Is(err, ErrProfileNotFound) {
+		return uuid.Nil, ErrFriendUserNotFound
+	}
+	return id, err
+}
+
+func (s *FriendService) userIDByCode(code string) (uuid.UUID, error) {
+	var invite models.InviteCode
+	if err := s.db.Where("code = ?", normalizeInviteCode(code)).First(&invite).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return uuid.Nil, ErrFriendUserNotFound
+		}
+		return uuid.Nil, err
+	}
+	return invite.UserID, nil
+}
+
+// friendStatus reports the relationship from userID's point of view
+func friendStatus(db *gorm.DB, userID, otherID uuid.UUID) (string, error) {
+	var friends int64
+	if err := db.Model(&models.Friendship{}).Where("user_id = ? AND friend_id = ?", userID, otherID).Count(&friends).Error; err != nil {
+		return "", err
+	}
+	if friends > 0 {
+		return FriendStatusFriends, nil
+	}
+
+	var requests []models.FriendRequest
+	if err := db.Where("(sender_id = ? AND receiver_id = ?) OR (sender_id = ? AND receiver_id = ?)", userID, otherID, otherID, userID).
+		Find(&requests).Error; err != nil {
+		return "", err
+	}
+	status := FriendStatusNone
+	for _, r := range requests {
+		if r.SenderID == otherID {
+			return FriendStatusIncoming, nil // An incoming request can be accepted, so it wins
+		}
+		status = FriendStatusOutgoing
+	}
+	return status, nil
+}
+
+// acceptBetween turns any requests between sender and receiver into a friendship
+func acceptBetween(tx *gorm.DB, senderID, receiverID uuid.UUID) error {
+	if err := checkFriendLimit(tx, senderID); err != nil {
+		return err
+	}
+	if err := checkFriendLimit(tx, receiverID); err != nil {
+		return err
+	}
+
+	if err := tx.Where("(sender_id = ? AND receiver_id = ?) OR (sender_id = ? AND receiver_id = ?)", senderID, receiverID, receiverID, senderID).
+		Delete(&models.FriendRequest{}).Error; err != nil {
+		return err
+	}
+	rows := []models.Friendship{
+		{ID: uuid.New(), UserID: senderID, FriendID: receiverID},
+		{ID: uuid.New(), UserID: receiverID, FriendID: senderID},
+	}
+	return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&rows).Error
+}
+
+func checkFriendLimit(tx *gorm.DB, userID uuid.UUID) error {
+	var count int64
+	if err := tx.Model(&models.Friendship{}).Where("user_id = ?", userID).Count(&count).Error; err != nil {
+		return err
+	}
+	if count >= maxFriends {
+		return ErrFriendLimitReached
+	}
+	return nil
+}
+
+func generateInviteCode() (string, error) {
+	rawBytes := make([]byte, inviteCodeLength)
+	if _, err := rand.Read(rawBytes); err != nil {
+		return "", fmt.Errorf("failed to generate random bytes: %w", err)
+	}
+	code := make([]byte, inviteCodeLength)
+	for i, b := range rawBytes {
+		code[i] = inviteCodeAlphabet[int(b)%len(inviteCodeAlphabet)]
+	}
+	return string(code), nil
+}
+
+// normalizeInviteCode accepts codes typed in lower case or with separators
+func normalizeInviteCode(code string) string {
+	return strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(strings.TrimSpace(code)))
+}
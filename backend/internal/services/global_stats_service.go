@@ -0,0 +1,311 @@
+package services
+
+import (
+	"log"
+	"math"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/dto"
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Anonymity thresholds for global aggregates
+const (
+	globalMinUsers       = 20 // k: a cohort needs this many users before anything about it is stored
+	globalMinCell        = 5  // Aesthetics used by fewer check-ins fold into "other"
+	globalBlockThreshold = 3  // Users blocked by this many people are left out of aggregates
+	globalScoreBands     = 10 // 10-point score bands kept for percentiles
+	globalOtherAesthetic = "other"
+)
+
+type GlobalStatsService struct {
+	db           *gorm.DB
+	settings     *SettingsService
+	noiseEpsilon float64 // Laplace noise privacy budget per value; 0 disables noise
+}
+
+func NewGlobalStatsService(db *gorm.DB, settings *SettingsService, noiseEpsilon float64) *GlobalStatsService {
+	return &GlobalStatsService{db: db, settings: settings, noiseEpsilon: noiseEpsilon}
+}
+
+// cohortAggregate accumulates one cohort's check-ins for a day
+type cohortAggregate struct {
+	users      int
+	total      int
+	aesthetics map[string]int
+	histogram  [globalScoreBands]int
+}
+
+// Aggregate recomputes the global and regional stats for a day and returns how
+// many cohorts were large enough to store. Guests, deleted accounts, banned
+// users (an actioned report against them) and widely blocked users are excluded.
+func (s *GlobalStatsService) Aggregate(day time.Time) (int, error) {
+	date := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+
+	var rows []struct {
+		Region    string
+		Aesthetic string
+		Band      int
+		Checks    int
+		Total     int
+	}
+	if err := s.db.Raw(`
+		SELECT COALESCE(NULLIF(SPLIT_PART(st.timezone, '/', 1), ''), 'UTC') AS region,
+			c.aesthetic,
+			GREATEST(LEAST(c.vibe_score / 10, ?), 0) AS band,
+			COUNT(*) AS checks,
+			SUM(c.vibe_score) AS total
+		FROM vibe_checks c
+		JOIN users u ON u.id = c.user_id AND u.deleted_at IS NULL
+		LEFT JOIN user_settings st ON st.user_id = c.user_id
+		WHERE c.deleted_at IS NULL AND c.check_date = ?
+			AND NOT EXISTS (
+				SELECT 1 FROM reports r
+				WHERE r.content_type = 'user' AND r.content_id = c.user_id::text AND r.status = 'actioned'
+			)
+			AND c.user_id NOT IN (
+				SELECT blocked_id FROM blocks GROUP BY blocked_id HAVING COUNT(DISTINCT blocker_id) >= ?
+			)
+		GROUP BY 1, 2, 3`,
+		globalScoreBands-1, date, globalBlockThreshold,
+	).Scan(&rows).Error; err != nil {
+		return 0, err
+	}
+
+	cohorts := make(map[string]*cohortAggregate)
+	add := func(cohort, aesthetic string, band, checks, total int) {
+		agg := cohorts[cohort]
+		if agg == nil {
+			agg = &cohortAggregate{aesthetics: make(map[string]int)}
+			cohorts[cohort] = agg
+		}
+		agg.users += checks // One check per user per day
+		agg.total += total
+		agg.aesthetics[aesthetic] += checks
+		agg.histogram[band] += checks
+	}
+	for _, r := range rows {
+		add(models.CohortGlobal, r.Aesthetic, r.Band, r.Checks, r.Total)
+		add(models.CohortRegionPrefix+r.Region, r.Aesthetic, r.Band, r.Checks, r.Total)
+	}
+
+	stored := []string{}
+	now := time.Now()
+	for cohort, agg := range cohorts {
+		if agg.users < globalMinUsers {
+			continue
+		}
+		stat := s.anonymize(agg)
+		stat.ID = uuid.New()
+		stat.Date = date
+		stat.Cohort = cohort
+		stat.ComputedAt = now
+		if err := s.db.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "date"}, {Name: "cohort"}},
+			DoUpdates: clause.AssignmentColumns([]string{"users", "avg_score", "aesthetics", "histogram", "noised", "computed_at"}),
+		}).Create(stat).Error; err != nil {
+			return 0, err
+		}
+		stored = append(stored, cohort)
+	}
+
+	// Cohorts that shrank below the threshold (deleted accounts, bans) must not linger
+	query := s.db.Where("date = ?", date)
+	if len(stored) > 0 {
+		query = query.Where("cohort NOT IN ?", stored)
+	}
+	if err := query.Delete(&models.GlobalVibeStat{}).Error; err != nil {
+		return 0, err
+	}
+	return len(stored), nil
+}
+
+// anonymize turns raw counts into a storable stat, folding rare aesthetics
+// into "other" and adding Laplace noise when enabled.
+func (s *GlobalStatsService) anonymize(agg *cohortAggregate) *models.GlobalVibeStat {
+	users := float64(agg.users)
+	avg := float64(agg.total) / users
+	aesthetics := make(map[string]float64)
+	for aesthetic, n := range agg.aesthetics {
+		if n < globalMinCell || aesthetic == "" {
+			aesthetic = globalOtherAesthetic
+		}
+		aesthetics[aesthetic] += float64(n)
+	}
+	histogram := make([]int, globalScoreBands)
+
+	noised := s.noiseEpsilon > 0
+	for i, n := range agg.histogram {
+		histogram[i] = n
+		if noised {
+			histogram[i] = max(0, int(math.Round(float64(n)+laplace(1/s.noiseEpsilon))))
+		}
+	}
+	if noised {
+		// A single user moves the average by at most 100/users
+		avg = clampScore(avg + laplace(100/(users*s.noiseEpsilon)))
+		users = math.Max(globalMinUsers, math.Round(users+laplace(1/s.noiseEpsilon)))
+		for aesthetic, n := range aesthetics {
+			aesthetics[aesthetic] = math.Max(0, n+laplace(1/s.noiseEpsilon))
+		}
+	}
+
+	var sum float64
+	for _, n := range aesthetics {
+		sum += n
+	}
+	for aesthetic, n := range aesthetics {
+		if sum > 0 {
+			aesthetics[aesthetic] = roundTo(n/sum, 3)
+		}
+		if aesthetics[aesthetic] == 0 {
+			delete(aesthetics, aesthetic)
+		}
+	}
+
+	return &models.GlobalVibeStat{
+		Users:      int(users),
+		AvgScore:   roundTo(avg, 1),
+		Aesthetics: aesthetics,
+		Histogram:  histogram,
+		Noised:     noised,
+	}
+}
+
+// GetComparison returns the stored stats for a day (today when zero) with the
+// user's score and percentile against everyone who checked in.
+func (s *GlobalStatsService) GetComparison(userID uuid.UUID, day time.Time) (*dto.GlobalComparisonResponse, error) {
+	if day.IsZero() {
+		day = time.Now()
+	}
+	date := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+
+	settings, err := s.settings.GetSettings(userID)
+	if err != nil {
+		return nil, err
+	}
+	region, _, _ := strings.Cut(settings.Timezone, "/")
+	if region == "" {
+		region = "UTC"
+	}
+	regionCohort := models.CohortRegionPrefix + region
+
+	var stats []models.GlobalVibeStat
+	if err := s.db.Where("date = ? AND cohort IN ?", date, []string{models.CohortGlobal, regionCohort}).
+		Find(&stats).Error; err != nil {
+		return nil, err
+	}
+
+	resp := &dto.GlobalComparisonResponse{Date: date.Format("2006-01-02")}
+	var global *models.GlobalVibeStat
+	for i := range stats {
+		stat := globalStatDTO(&stats[i])
+		switch stats[i].Cohort {
+		case models.CohortGlobal:
+			global = &stats[i]
+			resp.Global = stat
+			resp.UpdatedAt = stats[i].ComputedAt.Format(time.RFC3339)
+		case regionCohort:
+			resp.Cohort = stat
+		}
+	}
+
+	if err := s.fillScore(resp, userID, date, global); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// fillScore adds the user's own score for the day and where it falls in the
+// global score distribution.
+func (s *GlobalStatsService) fillScore(resp *dto.GlobalComparisonResponse, userID uuid.UUID, date time.Time, global *models.GlobalVibeStat) error {
+	var scores []int
+	if err := s.db.Model(&models.VibeCheck{}).
+		Where("user_id = ? AND check_date = ?", userID, date).
+		Limit(1).
+		Pluck("vibe_score", &scores).Error; err != nil {
+		return err
+	}
+	if len(scores) == 0 {
+		return nil
+	}
+	resp.YourScore = &scores[0]
+	if global != nil {
+		if pct, ok := scorePercentile(global.Histogram, scores[0]); ok {
+			resp.Percentile = &pct
+		}
+	}
+	return nil
+}
+
+// StartScheduler refreshes today's stats every interval, and finalizes the
+// previous day once after midnight, until stop is closed.
+func (s *GlobalStatsService) StartScheduler(interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var finalized time.Time
+	for {
+		now := time.Now().UTC()
+		today := now.Truncate(24 * time.Hour)
+		if yesterday := today.AddDate(0, 0, -1); !finalized.Equal(yesterday) {
+			if _, err := s.Aggregate(yesterday); err != nil {
+				log.Printf("Global stats aggregation failed for %s: %v", yesterday.Format("2006-01-02"), err)
+			} else {
+				finalized = yesterday
+			}
+		}
+		if _, err := s.Aggregate(today); err != nil {
+			log.Printf("Global stats aggregation failed for %s: %v", today.Format("2006-01-02"), err)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-stop:
+			return
+		}
+	}
+}
+
+func globalStatDTO(stat *models.GlobalVibeStat) *dto.GlobalStat {
+	return &dto.GlobalStat{
+		Cohort:     stat.Cohort,
+		Users:      stat.Users,
+		AvgScore:   stat.AvgScore,
+		Aesthetics: stat.Aesthetics,
+	}
+}
+
+// scorePercentile returns the percent of check-ins scoring below score,
+// interpolating within its 10-point band (the last band also holds 100).
+func scorePercentile(histogram []int, score int) (float64, bool) {
+	total := 0
+	for _, n := range histogram {
+		total += n
+	}
+	if total == 0 || len(histogram) != globalScoreBands {
+		return 0, false
+	}
+
+	band := max(0, min(score/10, globalScoreBands-1))
+	width := 10.0
+	if band == globalScoreBands-1 {
+		width = 11
+	}
+	below := 0.0
+	for _, n := range histogram[:band] {
+		below += float64(n)
+	}
+	below += float64(histogram[band]) * float64(score-band*10) / width
+	return roundTo(below/float64(total)*100, 1), true
+}
+
+// laplace draws zero-centred Laplace noise with the given scale
+func laplace(scale float64) float64 {
+	return scale * (rand.ExpFloat64() - rand.ExpFloat64())
+}
@@ -0,0 +1,174 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/dto"
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrInvalidReaction  = errors.New("invalid reaction emoji")
+	ErrCommentEmpty     = errors.New("comment cannot be empty")
+	ErrCommentTooLong   = errors.New("comment must be 280 characters or fewer")
+	ErrCommentRejected  = errors.New("comment contains prohibited content")
+	ErrCommentNotFound  = errors.New("comment not found")
+	ErrCommentForbidden = errors.New("you can only delete your own comments or comments on your check")
+)
+
+const (
+	maxCommentLength = 280
+	commentPageLimit = 100
+)
+
+// ReactionEmojis are the reactions a vibe check accepts
+var ReactionEmojis = map[string]bool{
+	"❤️": true, "🔥": true, "😂": true, "🥺": true, "🫂": true, "✨": true, "👏": true, "😮": true,
+}
+
+type InteractionService struct {
+	db         *gorm.DB
+	moderation *ModerationService
+}
+
+func NewInteractionService(db *gorm.DB, moderation *ModerationService) *InteractionService {
+	return &InteractionService{db: db, moderation: moderation}
+}
+
+// SetReaction adds or replaces the viewer's reaction on a check
+func (s *InteractionService) SetReaction(viewerID, checkID uuid.UUID, emoji string) error {
+	if !ReactionEmojis[emoji] {
+		return ErrInvalidReaction
+	}
+	if _, err := s.checkForViewer(viewerID, checkID); err != nil {
+		return err
+	}
+
+	reaction := models.Reaction{ID: uuid.New(), VibeCheckID: checkID, UserID: viewerID, Emoji: emoji}
+	return s.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "vibe_check_id"}, {Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"emoji", "updated_at"}),
+	}).Create(&reaction).Error
+}
+
+// RemoveReaction clears the viewer's reaction on a check
+func (s *InteractionService) RemoveReaction(viewerID, checkID uuid.UUID) error {
+	return s.db.Where("vibe_check_id = ? AND user_id = ?", checkID, viewerID).Delete(&models.Reaction{}).Error
+}
+
+// GetReactions returns a check's reactions, leaving out anyone on either side
+// of a block with the viewer
+func (s *InteractionService) GetReactions(viewerID, checkID uuid.UUID) (*dto.ReactionsResponse, error) {
+	if _, err := s.checkForViewer(viewerID, checkID); err != nil {
+		return nil, err
+	}
+
+	var reactions []models.Reaction
+	if err := s.db.Where("vibe_check_id = ?", checkID).
+		Where(notBlockedEither("reactions.user_id"), viewerID, viewerID).
+		Order("created_at ASC").
+		Find(&reactions).Error; err != nil {
+		return nil, err
+	}
+
+	resp := &dto.ReactionsResponse{Counts: make(map[string]int), Data: make([]dto.ReactionResponse, 0, len(reactions))}
+	for _, r := range reactions {
+		resp.Counts[r.Emoji]++
+		resp.Data = append(resp.Data, dto.ReactionResponse{UserID: r.UserID, Emoji: r.Emoji})
+		if r.UserID == viewerID {
+			resp.Mine = r.Emoji
+		}
+	}
+	return resp, nil
+}
+
+// AddComment posts a moderated comment on a check the viewer can see
+func (s *InteractionService) AddComment(viewerID, checkID uuid.UUID, body string) (*models.Comment, error) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return nil, ErrCommentEmpty
+	}
+	if utf8.RuneCountInString(body) > maxCommentLength {
+		return nil, ErrCommentTooLong
+	}
+	if clean, _ := s.moderation.FilterContent(body); !clean {
+		return nil, ErrCommentRejected
+	}
+	if _, err := s.checkForViewer(viewerID, checkID); err != nil {
+		return nil, err
+	}
+
+	comment := models.Comment{
+		ID:          uuid.New(),
+		VibeCheckID: checkID,
+		UserID:      viewerID,
+		Body:        s.moderation.SanitizeContent(body),
+	}
+	if err := s.db.Create(&comment).Error; err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// GetComments returns a check's comments, oldest first, hiding authors on
+// either side of a block with the viewer
+func (s *InteractionService) GetComments(viewerID, checkID uuid.UUID) ([]models.Comment, error) {
+	if _, err := s.checkForViewer(viewerID, checkID); err != nil {
+		return nil, err
+	}
+
+	var comments []models.Comment
+	if err := s.db.Where("vibe_check_id = ?", checkID).
+		Where(notBlockedEither("comments.user_id"), viewerID, viewerID).
+		Order("created_at ASC").
+		Limit(commentPageLimit).
+		Find(&comments).Error; err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
+
+// DeleteComment removes a comment written by the viewer or left on the viewer's check
+func (s *InteractionService) DeleteComment(viewerID, commentID uuid.UUID) error {
+	var comment models.Comment
+	if err := s.db.Where("id = ?", commentID).First(&comment).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrCommentNotFound
+		}
+		return err
+	}
+
+	if comment.UserID != viewerID {
+		var owned int64
+		if err := s.db.Model(&models.VibeCheck{}).
+			Where("id = ? AND user_id = ?", comment.VibeCheckID, viewerID).
+			Count(&owned).Error; err != nil {
+			return err
+		}
+		if owned == 0 {
+			return ErrCommentForbidden
+		}
+	}
+	return s.db.Delete(&comment).Error
+}
+
+// checkForViewer loads a check the viewer may interact with, which is any
+// check they may read. Anything else reads as not found.
+func (s *InteractionService) checkForViewer(viewerID, checkID uuid.UUID) (*models.VibeCheck, error) {
+	var check models.VibeCheck
+	if err := s.db.Table("vibe_checks AS c").
+		Where("c.id = ?", checkID).
+		Where(readableBy("c", checkReader{UserID: viewerID})).
+		Take(&check).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrVibeCheckNotFound
+		}
+		return nil, err
+	}
+	return &check, nil
+}
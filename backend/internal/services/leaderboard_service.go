@@ -0,0 +1,252 @@
+package services
+
+import (
+	"database/sql"
+	"log"
+	"time"
+
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/dto"
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	defaultLeaderboardWeeks = 8
+	maxLeaderboardWeeks     = 52
+)
+
+// leaderboardOrder ranks standings; user_id keeps ties stable
+const leaderboardOrder = "check_ins DESC, current_streak DESC, longest_streak DESC, user_id"
+
+type LeaderboardService struct {
+	db       *gorm.DB
+	circles  *CircleService
+	settings *SettingsService
+	streaks  *StreakService
+}
+
+func NewLeaderboardService(db *gorm.DB, circles *CircleService, settings *SettingsService, streaks *StreakService) *LeaderboardService {
+	return &LeaderboardService{db: db, circles: circles, settings: settings, streaks: streaks}
+}
+
+type leaderboardRow struct {
+	UserID        uuid.UUID
+	CheckIns      int
+	CurrentStreak int
+	LongestStreak int
+}
+
+// GetBoard ranks the viewer, their friends and everyone in their circles for
+// the current week. With a circle ID the board is that circle's members only.
+// Blocked users on either side and users who opted out are left off.
+func (s *LeaderboardService) GetBoard(viewerID uuid.UUID, circleID *uuid.UUID) (*dto.LeaderboardResponse, error) {
+	members, err := s.members(viewerID, circleID, "u.id")
+	if err != nil {
+		return nil, err
+	}
+
+	today := time.Now().Truncate(24 * time.Hour)
+	weekStart, weekEnd, _ := periodBounds(models.SummaryPeriodWeek, today)
+
+	// A streak is still live while freezes can cover the days since the last
+	// check-in; today doesn't count as missed until it's over
+	var rows []leaderboardRow
+	if err := s.db.Table("users AS u").
+		Select("u.id AS user_id, "+
+			"(SELECT COUNT(*) FROM vibe_checks c WHERE c.user_id = u.id AND c.check_date >= ? AND c.deleted_at IS NULL) AS check_ins, "+
+			"COALESCE(CASE WHEN st.last_check_date >= CAST(? AS date) - (1 + st.freezes_available) THEN st.current_streak END, 0) AS current_streak, "+
+			"COALESCE(st.longest_streak, 0) AS longest_streak", weekStart, today).
+		Joins("LEFT JOIN vibe_streaks st ON st.user_id = u.id AND st.deleted_at IS NULL").
+		Where("u.deleted_at IS NULL").
+		Where(members).
+		Order(leaderboardOrder).
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	settings, err := s.settings.GetSettings(viewerID)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := s.entries(rows, viewerID)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.LeaderboardResponse{
+		WeekStart: weekStart.Format("2006-01-02"),
+		WeekEnd:   weekEnd.Format("2006-01-02"),
+		ResetsAt:  weekEnd.AddDate(0, 0, 1).Format(time.RFC3339),
+		Hidden:    settings.HideFromLeaderboard,
+		Data:      entries,
+	}, nil
+}
+
+// GetHistory returns the winner of each of the last closed weeks among the
+// same people GetBoard would rank today
+func (s *LeaderboardService) GetHistory(viewerID uuid.UUID, circleID *uuid.UUID, weeks int) (*dto.LeaderboardHistoryResponse, error) {
+	if weeks < 1 || weeks > maxLeaderboardWeeks {
+		weeks = defaultLeaderboardWeeks
+	}
+	members, err := s.members(viewerID, circleID, "r.user_id")
+	if err != nil {
+		return nil, err
+	}
+
+	currentStart, _, _ := periodBounds(models.SummaryPeriodWeek, time.Now())
+	var results []models.LeaderboardResult
+	if err := s.db.Table("leaderboard_results AS r").
+		Select("DISTINCT ON (r.week_start) r.*").
+		Joins("JOIN users u ON u.id = r.user_id AND u.deleted_at IS NULL").
+		Where("r.week_start >= ?", currentStart.AddDate(0, 0, -7*weeks)).
+		Where(members).
+		Order("r.week_start DESC, r.check_ins DESC, r.current_streak DESC, r.longest_streak DESC, r.user_id").
+		Scan(&results).Error; err != nil {
+		return nil, err
+	}
+
+	rows := make([]leaderboardRow, len(results))
+	for i, r := range results {
+		rows[i] = leaderboardRow{UserID: r.UserID, CheckIns: r.CheckIns, CurrentStreak: r.CurrentStreak, LongestStreak: r.LongestStreak}
+	}
+	entries, err := s.entries(rows, viewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.LeaderboardHistoryResponse{Data: make([]dto.LeaderboardWinner, 0, len(results))}
+	for i, r := range results {
+		winner := entries[i]
+		winner.Rank = 1
+		resp.Data = append(resp.Data, dto.LeaderboardWinner{
+			WeekStart: r.WeekStart.Format("2006-01-02"),
+			WeekEnd:   r.WeekStart.AddDate(0, 0, 6).Format("2006-01-02"),
+			Winner:    winner,
+		})
+	}
+	return resp, nil
+}
+
+// CloseWeek freezes the standing of everyone who checked in during the most
+// recently closed week. Streaks are rebuilt from history as of the week's end,
+// so a late run, or a check-in on Monday before it, records the same results
+// as a punctual one. Users already recorded are skipped, so the scheduler can
+// run it as often as it likes.
+func (s *LeaderboardService) CloseWeek(now time.Time) (int64, error) {
+	currentStart, _, _ := periodBounds(models.SummaryPeriodWeek, now)
+	start, end, _ := periodBounds(models.SummaryPeriodWeek, currentStart.AddDate(0, 0, -1))
+
+	var weekly []leaderboardRow
+	if err := s.db.Table("vibe_checks AS c").
+		Select("c.user_id, COUNT(*) AS check_ins").
+		Where("c.user_id IS NOT NULL AND c.deleted_at IS NULL AND c.check_date >= ? AND c.check_date <= ?", start, end).
+		Where("NOT EXISTS (SELECT 1 FROM leaderboard_results r WHERE r.week_start = ? AND r.user_id = c.user_id)", start).
+		Group("c.user_id").
+		Scan(&weekly).Error; err != nil {
+		return 0, err
+	}
+
+	var recorded int64
+	for _, row := range weekly {
+		streak, err := s.streaks.streakAt(row.UserID, end)
+		if err != nil {
+			return recorded, err
+		}
+		result := s.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.LeaderboardResult{
+			WeekStart:     start,
+			UserID:        row.UserID,
+			CheckIns:      row.CheckIns,
+			CurrentStreak: streak.CurrentStreak,
+			LongestStreak: streak.LongestStreak,
+		})
+		if result.Error != nil {
+			return recorded, result.Error
+		}
+		recorded += result.RowsAffected
+	}
+	return recorded, nil
+}
+
+// StartScheduler closes finished leaderboard weeks every interval until stop is closed
+func (s *LeaderboardService) StartScheduler(interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if n, err := s.CloseWeek(time.Now()); err != nil {
+			log.Printf("Leaderboard week close failed: %v", err)
+		} else if n > 0 {
+			log.Printf("Recorded %d leaderboard results", n)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-stop:
+			return
+		}
+	}
+}
+
+// members is the condition limiting the user column to the viewer's board:
+// the viewer, friends and circle-mates, or one circle's members. Blocked and
+// opted-out users are excluded; the viewer always sees themselves unless they
+// opted out. The board is gathered as its own small set and filtered there,
+// so queries are driven by it instead of testing every user against it.
+func (s *LeaderboardService) members(viewerID uuid.UUID, circleID *uuid.UUID, column string) (clause.Expression, error) {
+	board := "SELECT CAST(@viewer AS uuid) AS user_id" +
+		" UNION SELECT f.friend_id FROM friendships f WHERE f.user_id = @viewer" +
+		" UNION SELECT theirs.user_id FROM circle_members mine" +
+		" JOIN circle_members theirs ON theirs.circle_id = mine.circle_id" +
+		" JOIN circles ci ON ci.id = mine.circle_id AND ci.deleted_at IS NULL" +
+		" WHERE mine.user_id = @viewer"
+	circle := uuid.Nil
+	if circleID != nil {
+		if _, _, err := s.circles.circleForMember(viewerID, *circleID); err != nil {
+			return nil, err
+		}
+		circle = *circleID
+		board = "SELECT m.user_id FROM circle_members m WHERE m.circle_id = @circle"
+	}
+
+	return clause.NamedExpr{
+		SQL: column + " IN (SELECT board.user_id FROM (" + board + ") board" +
+			" WHERE NOT EXISTS (SELECT 1 FROM blocks b WHERE (b.blocker_id = @viewer AND b.blocked_id = board.user_id) OR (b.blocker_id = board.user_id AND b.blocked_id = @viewer))" +
+			" AND NOT EXISTS (SELECT 1 FROM user_settings us WHERE us.user_id = board.user_id AND us.hide_from_leaderboard))",
+		Vars: []any{sql.Named("viewer", viewerID), sql.Named("circle", circle)},
+	}, nil
+}
+
+// entries ranks ordered rows and attaches profiles. Rows tied on every metric
+// share a rank.
+func (s *LeaderboardService) entries(rows []leaderboardRow, viewerID uuid.UUID) ([]dto.LeaderboardEntry, error) {
+	ids := make([]uuid.UUID, len(rows))
+	for i := range rows {
+		ids[i] = rows[i].UserID
+	}
+	summaries, err := profileSummaries(s.db, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]dto.LeaderboardEntry, 0, len(rows))
+	for i, r := range rows {
+		rank := i + 1
+		if i > 0 {
+			prev := rows[i-1]
+			if prev.CheckIns == r.CheckIns && prev.CurrentStreak == r.CurrentStreak && prev.LongestStreak == r.LongestStreak {
+				rank = entries[i-1].Rank
+			}
+		}
+		entries = append(entries, dto.LeaderboardEntry{
+			Rank:          rank,
+			UserID:        r.UserID,
+			Profile:       summaries[r.UserID],
+			CheckIns:      r.CheckIns,
+			CurrentStreak: r.CurrentStreak,
+			LongestStreak: r.LongestStreak,
+			IsYou:         r.UserID == viewerID,
+		})
+	}
+	return entries, nil
+}
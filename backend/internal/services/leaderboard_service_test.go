@@ -0,0 +1,137 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/models"
+	"github.com/google/uuid"
+)
+
+func TestLeaderboardBoardMembers(t *testing.T) {
+	db := testDB(t)
+	moderation := NewModerationService(db)
+	leaderboard := NewLeaderboardService(db, NewCircleService(db, moderation), NewSettingsService(db), NewStreakService(db))
+
+	// The owner checked in today; around them are a friend, a circle mate, a
+	// stranger, a blocked friend, and a friend who opted out
+	f := newVisibilityFixture(t, db, models.VisibilityFriends, false)
+	optedOut := createTestUser(t, db).ID
+	mustCreate(t, db,
+		&models.Friendship{UserID: f.owner, FriendID: optedOut},
+		&models.Friendship{UserID: optedOut, FriendID: f.owner},
+		&models.UserSettings{UserID: optedOut, HideFromLeaderboard: true},
+	)
+
+	tests := []struct {
+		name   string
+		circle *uuid.UUID
+		want   []uuid.UUID
+	}{
+		{"friends and circles", nil, []uuid.UUID{f.owner, f.readers["friend"], f.readers["circle mate"]}},
+		{"one circle", &f.circle, []uuid.UUID{f.owner, f.readers["circle mate"]}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			board, err := leaderboard.GetBoard(f.owner, tt.circle)
+			if err != nil {
+				t.Fatalf("GetBoard: %v", err)
+			}
+
+			want := make(map[uuid.UUID]bool, len(tt.want))
+			for _, id := range tt.want {
+				want[id] = true
+			}
+			for _, entry := range board.Data {
+				if !want[entry.UserID] {
+					t.Errorf("unexpected user %s on the board", entry.UserID)
+				}
+				delete(want, entry.UserID)
+
+				if entry.UserID == f.owner && (!entry.IsYou || entry.CheckIns != 1 || entry.Rank != 1) {
+					t.Errorf("viewer's entry = %+v, want first with 1 check-in", entry)
+				}
+			}
+			for id := range want {
+				t.Errorf("user %s missing from the board", id)
+			}
+		})
+	}
+}
+
+func TestCloseWeekRecordsStandingsAtWeekEnd(t *testing.T) {
+	db := testDB(t)
+	streaks := NewStreakService(db)
+	moderation := NewModerationService(db)
+	leaderboard := NewLeaderboardService(db, NewCircleService(db, moderation), NewSettingsService(db), streaks)
+
+	// The week of Mon 3 March 2025, closed late on Wednesday 12th
+	march := func(day int) time.Time { return time.Date(2025, time.March, day, 0, 0, 0, 0, time.UTC) }
+	checkIns := func(user uuid.UUID, days ...int) {
+		t.Helper()
+		for _, d := range days {
+			mustCreate(t, db, &models.VibeCheck{UserID: &user, MoodText: "steady", VibeScore: 60, CheckDate: march(d)})
+		}
+	}
+	event := func(user uuid.UUID, eventType string, day int, note string, loggedOn int) *models.StreakEvent {
+		return &models.StreakEvent{UserID: user, Type: eventType, EventDate: march(day), Note: note, CreatedAt: march(loggedOn).Add(12 * time.Hour)}
+	}
+
+	// Kept going past Sunday; the late run mustn't count Monday onwards
+	kept := createTestUser(t, db).ID
+	checkIns(kept, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12)
+
+	// Stopped on Thursday with no freezes to cover the days since
+	stopped := createTestUser(t, db).ID
+	checkIns(stopped, 3, 4, 5, 6)
+
+	// Missed the weekend holding two freezes, spent at Monday's check-in
+	frozen := createTestUser(t, db).ID
+	checkIns(frozen, 3, 4, 5, 6, 7, 10)
+	mustCreate(t, db,
+		event(frozen, StreakEventFreezeGranted, 5, "premium: +2", 5),
+		event(frozen, StreakEventFreezeUsed, 8, "", 10),
+		event(frozen, StreakEventFreezeUsed, 9, "", 10),
+	)
+
+	// Missed the weekend and only got freezes on Monday
+	lateGrant := createTestUser(t, db).ID
+	checkIns(lateGrant, 3, 4, 5, 6, 7, 10)
+	mustCreate(t, db,
+		event(lateGrant, StreakEventFreezeGranted, 10, "premium: +2", 10),
+		event(lateGrant, StreakEventFreezeUsed, 8, "", 10),
+		event(lateGrant, StreakEventFreezeUsed, 9, "", 10),
+	)
+
+	if _, err := leaderboard.CloseWeek(march(12)); err != nil {
+		t.Fatalf("CloseWeek: %v", err)
+	}
+
+	tests := []struct {
+		name                                   string
+		user                                   uuid.UUID
+		checkIns, currentStreak, longestStreak int
+	}{
+		{"kept going", kept, 7, 7, 7},
+		{"stopped", stopped, 4, 0, 4},
+		{"covered by freezes held", frozen, 5, 5, 5},
+		{"freezes granted after the week", lateGrant, 5, 0, 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var result models.LeaderboardResult
+			if err := db.Where("week_start = ? AND user_id = ?", march(3), tt.user).First(&result).Error; err != nil {
+				t.Fatalf("load result: %v", err)
+			}
+			if result.CheckIns != tt.checkIns || result.CurrentStreak != tt.currentStreak || result.LongestStreak != tt.longestStreak {
+				t.Errorf("result = %d check-ins, streak %d, longest %d; want %d, %d, %d",
+					result.CheckIns, result.CurrentStreak, result.LongestStreak, tt.checkIns, tt.currentStreak, tt.longestStreak)
+			}
+		})
+	}
+
+	if n, err := leaderboard.CloseWeek(march(13)); err != nil || n != 0 {
+		t.Errorf("closing the week again recorded %d results (err %v), want 0", n, err)
+	}
+}
@@ -16,6 +16,8 @@ var (
 	ErrReportNotFound = errors.New("report not found")
 	ErrAlreadyBlocked = errors.New("user already blocked")
 	ErrSelfBlock      = errors.New("cannot block yourself")
+
+	ErrReportTargetNotFound = errors.New("reported content not found")
 )
 
 // ProfanityPatterns is a basic regex-based content filter (Apple Guideline 1.2).
@@ -57,15 +59,18 @@ func (s *ModerationService) SanitizeContent(text string) string {
 // --- Reports ---
 
 func (s *ModerationService) CreateReport(reporterID uuid.UUID, req *dto.CreateReportRequest) (*models.Report, error) {
-	validTypes := map[string]bool{"user": true, "post": true, "comment": true}
-	if !validTypes[req.ContentType] {
-		return nil, errors.New("invalid content_type: must be user, post, or comment")
+	if !reportTypes[req.ContentType] {
+		return nil, errors.New("invalid content_type: must be user, post, comment, or discover")
 	}
 
 	if strings.TrimSpace(req.Reason) == "" {
 		return nil, errors.New("reason is required")
 	}
 
+	if err := s.validateReportTarget(req.ContentType, req.ContentID); err != nil {
+		return nil, err
+	}
+
 	report := models.Report{
 		ID:          uuid.New(),
 		ReporterID:  reporterID,
@@ -79,9 +84,48 @@ func (s *ModerationService) CreateReport(reporterID uuid.UUID, req *dto.CreateRe
 		return nil, fmt.Errorf("failed to create report: %w", err)
 	}
 
+	if report.ContentType == "discover" {
+		if err := s.hideOverReported(report.ContentID); err != nil {
+			return nil, err
+		}
+	}
+
 	return &report, nil
 }
 
+// reportTypes are the content types that can be reported. A "post" is a vibe
+// check and "discover" an entry in the discover stream.
+var reportTypes = map[string]bool{"user": true, "post": true, "comment": true, "discover": true}
+
+// reportTargets maps the content types whose reported ID must exist to the
+// table holding that content. Comment and discover reports act on their
+// target; user and post reports keep accepting any content_id as before.
+var reportTargets = map[string]interface{}{
+	"comment":  &models.Comment{},
+	"discover": &models.DiscoverEntry{},
+}
+
+// validateReportTarget checks that the reported content ID exists, for the
+// content types in reportTargets
+func (s *ModerationService) validateReportTarget(contentType, contentID string) error {
+	target, ok := reportTargets[contentType]
+	if !ok {
+		return nil
+	}
+	id, err := uuid.Parse(contentID)
+	if err != nil {
+		return errors.New("invalid content_id: must be a UUID")
+	}
+	var count int64
+	if err := s.db.Model(target).Where("id = ?", id).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return ErrReportTargetNotFound
+	}
+	return nil
+}
+
 func (s *ModerationService) ListReports(status string, limit, offset int) ([]models.Report, int64, error) {
 	var reports []models.Report
 	var total int64
@@ -116,8 +160,65 @@ func (s *ModerationService) ActionReport(reportID uuid.UUID, req *dto.ActionRepo
 	if result.RowsAffected == 0 {
 		return ErrReportNotFound
 	}
+	if result.Error != nil {
+		return result.Error
+	}
 
-	return result.Error
+	var report models.Report
+	if err := s.db.Where("id = ?", reportID).First(&report).Error; err != nil {
+		return err
+	}
+
+	// Actioning a comment report takes the comment down
+	if req.Status == "actioned" && report.ContentType == "comment" {
+		return s.db.Where("id = ?", report.ContentID).Delete(&models.Comment{}).Error
+	}
+	if report.ContentType == "discover" {
+		return s.reviewDiscoverEntry(&report, req)
+	}
+	return nil
+}
+
+// hideOverReported auto-hides a discover entry once enough different users
+// have pending reports against it. It stays hidden until an admin reviews it.
+func (s *ModerationService) hideOverReported(entryID string) error {
+	var reporters int64
+	if err := s.db.Model(&models.Report{}).
+		Where("content_type = ? AND content_id = ? AND status = ?", "discover", entryID, "pending").
+		Distinct("reporter_id").
+		Count(&reporters).Error; err != nil {
+		return err
+	}
+	if reporters < discoverHideThreshold {
+		return nil
+	}
+	return s.db.Model(&models.DiscoverEntry{}).
+		Where("id = ? AND status = ?", entryID, models.DiscoverStatusApproved).
+		Update("status", models.DiscoverStatusHidden).Error
+}
+
+// reviewDiscoverEntry applies an admin decision on a discover report.
+// Actioned takes the entry down for good. Dismissed restores a hidden entry
+// and closes the other pending reports on it, so they can't re-hide it.
+func (s *ModerationService) reviewDiscoverEntry(report *models.Report, req *dto.ActionReportRequest) error {
+	switch req.Status {
+	case "actioned":
+		return s.db.Model(&models.DiscoverEntry{}).
+			Where("id = ?", report.ContentID).
+			Update("status", models.DiscoverStatusRemoved).Error
+	case "dismissed":
+		return s.db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Model(&models.Report{}).
+				Where("content_type = ? AND content_id = ? AND status = ?", "discover", report.ContentID, "pending").
+				Updates(map[string]interface{}{"status": "dismissed", "admin_note": req.AdminNote}).Error; err != nil {
+				return err
+			}
+			return tx.Model(&models.DiscoverEntry{}).
+				Where("id = ? AND status = ?", report.ContentID, models.DiscoverStatusHidden).
+				Update("status", models.DiscoverStatusApproved).Error
+		})
+	}
+	return nil
 }
 
 // --- Blocking ---
@@ -138,7 +239,18 @@ func (s *ModerationService) BlockUser(blockerID, blockedID uuid.UUID) error {
 		BlockedID: blockedID,
 	}
 
-	return s.db.Create(&block).Error
+	// A block ends any friendship or pending request between the two
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&block).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("(user_id = ? AND friend_id = ?) OR (user_id = ? AND friend_id = ?)", blockerID, blockedID, blockedID, blockerID).
+			Delete(&models.Friendship{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("(sender_id = ? AND receiver_id = ?) OR (sender_id = ? AND receiver_id = ?)", blockerID, blockedID, blockedID, blockerID).
+			Delete(&models.FriendRequest{}).Error
+	})
 }
 
 func (s *ModerationService) UnblockUser(blockerID, blockedID uuid.UUID) error {
@@ -158,3 +270,12 @@ func (s *ModerationService) GetBlockedIDs(userID uuid.UUID) ([]uuid.UUID, error)
 	}
 	return ids, nil
 }
+
+// IsBlockedEither reports whether either user has blocked the other
+func (s *ModerationService) IsBlockedEither(userID, otherID uuid.UUID) (bool, error) {
+	var count int64
+	err := s.db.Model(&models.Block{}).
+		Where("(blocker_id = ? AND blocked_id = ?) OR (blocker_id = ? AND blocked_id = ?)", userID, otherID, otherID, userID).
+		Count(&count).Error
+	return count > 0, err
+}
@@ -0,0 +1,65 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/dto"
+	"github.com/google/uuid"
+)
+
+func TestValidateReportTargetSkipsUserAndPost(t *testing.T) {
+	// No database: user and post IDs are taken as given, and a malformed
+	// comment or discover ID is rejected before any lookup
+	moderation := &ModerationService{}
+
+	tests := []struct {
+		contentType, contentID string
+		wantErr                bool
+	}{
+		{"user", "not-a-uuid", false},
+		{"user", "6f1c2a4e-3b7d-4e8a-9c10-2d5f7b8e9a01", false},
+		{"post", "legacy-post-42", false},
+		{"comment", "not-a-uuid", true},
+		{"discover", "not-a-uuid", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.contentType+" "+tt.contentID, func(t *testing.T) {
+			err := moderation.validateReportTarget(tt.contentType, tt.contentID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateReportTarget = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCreateReportTargets(t *testing.T) {
+	db := testDB(t)
+	moderation := NewModerationService(db)
+	reporter := createTestUser(t, db)
+
+	report := func(contentType, contentID string) error {
+		_, err := moderation.CreateReport(reporter.ID, &dto.CreateReportRequest{
+			ContentType: contentType,
+			ContentID:   contentID,
+			Reason:      "test",
+		})
+		return err
+	}
+
+	// User reports keep working for IDs the server can't check
+	if err := report("user", "not-a-uuid"); err != nil {
+		t.Errorf("user report with a free-form ID: %v", err)
+	}
+	if err := report("user", createTestUser(t, db).ID.String()); err != nil {
+		t.Errorf("user report: %v", err)
+	}
+
+	if err := report("comment", uuid.NewString()); !errors.Is(err, ErrReportTargetNotFound) {
+		t.Errorf("report of a missing comment: got %v, want ErrReportTargetNotFound", err)
+	}
+	if err := report("discover", uuid.NewString()); !errors.Is(err, ErrReportTargetNotFound) {
+		t.Errorf("report of a missing discover entry: got %v, want ErrReportTargetNotFound", err)
+	}
+}
@@ -0,0 +1,210 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/dto"
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Pattern thresholds
+const (
+	minPatternChecks     = 14  // Total check-ins before any pattern is claimed
+	minPatternGroup      = 3   // Check-ins a weekday/time bucket needs to be considered
+	minPatternDelta      = 5.0 // Points from the overall average worth mentioning
+	minDominantAesthetic = 0.5 // Share of a group one aesthetic needs to be called dominant
+)
+
+// Pattern confidence levels
+const (
+	ConfidenceNone   = "none"
+	ConfidenceLow    = "low"
+	ConfidenceMedium = "medium"
+	ConfidenceHigh   = "high"
+)
+
+// timeOfDayBuckets split the local day; night wraps past midnight
+var timeOfDayBuckets = []struct {
+	Label      string
+	Plural     string
+	Start, End int // [Start, End) local hours
+}{
+	{"Morning", "mornings", 5, 12},
+	{"Afternoon", "afternoons", 12, 17},
+	{"Evening", "evenings", 17, 22},
+	{"Night", "nights", 22, 29},
+}
+
+type PatternService struct {
+	db       *gorm.DB
+	settings *SettingsService
+}
+
+func NewPatternService(db *gorm.DB, settings *SettingsService) *PatternService {
+	return &PatternService{db: db, settings: settings}
+}
+
+// patternSample is one check-in placed in the user's local time
+type patternSample struct {
+	local     time.Time
+	score     int
+	aesthetic string
+}
+
+// GetPatterns groups the user's check-ins by local weekday and time of day and
+// turns meaningful differences from their overall average into statements.
+func (s *PatternService) GetPatterns(userID uuid.UUID) (*dto.PatternsResponse, error) {
+	loc := s.settings.Location(userID)
+
+	var checks []models.VibeCheck
+	if err := s.db.Select("created_at", "vibe_score", "aesthetic").
+		Where("user_id = ?", userID).
+		Find(&checks).Error; err != nil {
+		return nil, err
+	}
+
+	resp := &dto.PatternsResponse{
+		Timezone:    loc.String(),
+		TotalChecks: len(checks),
+		MinChecks:   minPatternChecks,
+		Ready:       len(checks) >= minPatternChecks,
+		Weekdays:    []dto.PatternGroup{},
+		TimesOfDay:  []dto.PatternGroup{},
+		Statements:  []string{},
+	}
+	if len(checks) == 0 {
+		return resp, nil
+	}
+
+	samples := make([]patternSample, 0, len(checks))
+	total := 0
+	for _, check := range checks {
+		samples = append(samples, patternSample{local: check.CreatedAt.In(loc), score: check.VibeScore, aesthetic: check.Aesthetic})
+		total += check.VibeScore
+	}
+	mean := float64(total) / float64(len(samples))
+	var variance float64
+	for _, sample := range samples {
+		variance += math.Pow(float64(sample.score)-mean, 2)
+	}
+	stddev := math.Sqrt(variance / float64(len(samples)))
+	resp.OverallAvg = roundTo(mean, 1)
+
+	type statement struct {
+		text     string
+		strength float64
+	}
+	var statements []statement
+
+	// Weekdays, Monday first
+	for i := 0; i < 7; i++ {
+		weekday := time.Weekday((i + 1) % 7)
+		group := buildPatternGroup(weekday.String(), samples, mean, stddev, func(t time.Time) bool {
+			return t.Weekday() == weekday
+		})
+		resp.Weekdays = append(resp.Weekdays, group)
+		if text, ok := patternStatement(group, "Your "+weekday.String()+"s", resp.Ready); ok {
+			statements = append(statements, statement{text, math.Abs(group.Delta)})
+		}
+		if group.DominantAesthetic != "" && resp.Ready && group.Confidence != ConfidenceNone {
+			statements = append(statements, statement{
+				fmt.Sprintf("%ss are usually %s", weekday.String(), group.DominantAesthetic), 0,
+			})
+		}
+	}
+
+	for _, bucket := range timeOfDayBuckets {
+		bucket := bucket
+		group := buildPatternGroup(bucket.Label, samples, mean, stddev, func(t time.Time) bool {
+			h := t.Hour()
+			if h < bucket.Start {
+				h += 24
+			}
+			return h >= bucket.Start && h < bucket.End
+		})
+		resp.TimesOfDay = append(resp.TimesOfDay, group)
+		if text, ok := patternStatement(group, "Your "+bucket.Plural, resp.Ready); ok {
+			statements = append(statements, statement{text, math.Abs(group.Delta)})
+		}
+	}
+
+	sort.SliceStable(statements, func(i, j int) bool { return statements[i].strength > statements[j].strength })
+	for _, st := range statements {
+		resp.Statements = append(resp.Statements, st.text)
+	}
+
+	return resp, nil
+}
+
+// buildPatternGroup summarizes the samples whose local time matches
+func buildPatternGroup(label string, samples []patternSample, mean, stddev float64, match func(time.Time) bool) dto.PatternGroup {
+	group := dto.PatternGroup{Label: label, Confidence: ConfidenceNone}
+
+	total := 0
+	aesthetics := make(map[string]int)
+	for _, sample := range samples {
+		if !match(sample.local) {
+			continue
+		}
+		group.Checks++
+		total += sample.score
+		aesthetics[sample.aesthetic]++
+	}
+	if group.Checks == 0 {
+		return group
+	}
+
+	avg := float64(total) / float64(group.Checks)
+	rounded := roundTo(avg, 1)
+	group.AvgScore = &rounded
+	group.Delta = roundTo(avg-mean, 1)
+	group.Confidence = patternConfidence(group.Checks, avg-mean, stddev)
+
+	for aesthetic, count := range aesthetics {
+		if aesthetic != "" && float64(count)/float64(group.Checks) >= minDominantAesthetic && group.Checks >= minPatternGroup {
+			group.DominantAesthetic = aesthetic
+		}
+	}
+	return group
+}
+
+// patternConfidence rates a group difference by sample size and how many
+// standard errors it sits from the overall mean.
+func patternConfidence(n int, delta, stddev float64) string {
+	if n < minPatternGroup {
+		return ConfidenceNone
+	}
+	if stddev == 0 {
+		return ConfidenceLow
+	}
+	z := math.Abs(delta) / (stddev / math.Sqrt(float64(n)))
+	switch {
+	case n >= 8 && z >= 2.5:
+		return ConfidenceHigh
+	case n >= 5 && z >= 2:
+		return ConfidenceMedium
+	case z >= 1:
+		return ConfidenceLow
+	}
+	return ConfidenceNone
+}
+
+// patternStatement phrases a score difference, e.g. "Your Mondays average 18 points lower"
+func patternStatement(group dto.PatternGroup, subject string, ready bool) (string, bool) {
+	if !ready || math.Abs(group.Delta) < minPatternDelta {
+		return "", false
+	}
+	if group.Confidence != ConfidenceMedium && group.Confidence != ConfidenceHigh {
+		return "", false
+	}
+	direction := "higher"
+	if group.Delta < 0 {
+		direction = "lower"
+	}
+	return fmt.Sprintf("%s average %.0f points %s", subject, math.Abs(group.Delta), direction), true
+}
@@ -0,0 +1,360 @@
+package services
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	_ "image/png" // Registers PNG for avatar uploads
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/dto"
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/models"
+	"github.com/google/uuid"
+	"golang.org/x/image/draw"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrProfileNotFound     = errors.New("profile not found")
+	ErrUsernameInvalid     = errors.New("username must be 3-20 letters, digits or underscores and start with a letter")
+	ErrUsernameTaken       = errors.New("username is already taken")
+	ErrUsernameRejected    = errors.New("username contains prohibited content")
+	ErrDisplayNameTooLong  = errors.New("display name must be 50 characters or fewer")
+	ErrDisplayNameRejected = errors.New("display name contains prohibited content")
+	ErrBioTooLong          = errors.New("bio must be 160 characters or fewer")
+	ErrBioRejected         = errors.New("bio contains prohibited content")
+	ErrInvalidAvatarEmoji  = errors.New("avatar emoji must be a single emoji")
+	ErrInvalidAvatarColor  = errors.New("avatar color must be a hex color like #A1B2C3")
+	ErrInvalidAvatarImage  = errors.New("avatar must be a PNG or JPEG image up to 4096x4096")
+)
+
+// Profile limits
+const (
+	maxDisplayNameLength = 50
+	maxBioLength         = 160
+	maxAvatarEmojiRunes  = 8 // Flags and ZWJ sequences span several runes
+	maxAvatarSourceSide  = 4096
+	avatarSize           = 256
+	avatarJPEGQuality    = 85
+)
+
+var (
+	usernamePattern    = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]{2,19}$`)
+	avatarColorPattern = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
+)
+
+// reservedUsernames can't be claimed, so nobody can pose as staff or a route
+var reservedUsernames = map[string]bool{
+	"admin": true, "administrator": true, "support": true, "help": true, "vibecheck": true,
+	"moderator": true, "mod": true, "staff": true, "official": true, "system": true,
+	"root": true, "api": true, "me": true, "null": true, "undefined": true,
+}
+
+type ProfileService struct {
+	db         *gorm.DB
+	moderation *ModerationService
+}
+
+func NewProfileService(db *gorm.DB, moderation *ModerationService) *ProfileService {
+	return &ProfileService{db: db, moderation: moderation}
+}
+
+// GetMe returns the caller's account email and profile
+func (s *ProfileService) GetMe(userID uuid.UUID) (*dto.MeResponse, error) {
+	var user models.User
+	if err := s.db.Where("id = ?", userID).First(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrProfileNotFound
+		}
+		return nil, err
+	}
+	profile, err := s.getProfile(userID)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.MeResponse{ProfileResponse: profileResponse(profile, len(profile.AvatarImage) > 0), Email: user.Email}, nil
+}
+
+// UpdateMe validates and moderates the set fields, then upserts the profile
+func (s *ProfileService) UpdateMe(userID uuid.UUID, req *dto.UpdateProfileRequest) (*dto.MeResponse, error) {
+	profile, err := s.getProfile(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Username != nil {
+		username := strings.TrimSpace(*req.Username)
+		if !usernamePattern.MatchString(username) {
+			return nil, ErrUsernameInvalid
+		}
+		key := strings.ToLower(username)
+		if reservedUsernames[key] {
+			return nil, ErrUsernameTaken
+		}
+		if clean, _ := s.moderation.FilterContent(username); !clean {
+			return nil, ErrUsernameRejected
+		}
+		profile.Username, profile.UsernameKey = &username, &key
+	}
+	if req.DisplayName != nil {
+		name := strings.TrimSpace(*req.DisplayName)
+		if utf8.RuneCountInString(name) > maxDisplayNameLength {
+			return nil, ErrDisplayNameTooLong
+		}
+		if clean, _ := s.moderation.FilterContent(name); !clean {
+			return nil, ErrDisplayNameRejected
+		}
+		profile.DisplayName = name
+	}
+	if req.Bio != nil {
+		bio := strings.TrimSpace(*req.Bio)
+		if utf8.RuneCountInString(bio) > maxBioLength {
+			return nil, ErrBioTooLong
+		}
+		if clean, _ := s.moderation.FilterContent(bio); !clean {
+			return nil, ErrBioRejected
+		}
+		profile.Bio = bio
+	}
+	if req.AvatarEmoji != nil || req.AvatarColor != nil {
+		if req.AvatarEmoji != nil {
+			emoji := strings.TrimSpace(*req.AvatarEmoji)
+			if emoji == "" || utf8.RuneCountInString(emoji) > maxAvatarEmojiRunes || isASCII(emoji) {
+				return nil, ErrInvalidAvatarEmoji
+			}
+			profile.AvatarEmoji = emoji
+		}
+		if req.AvatarColor != nil {
+			if !avatarColorPattern.MatchString(*req.AvatarColor) {
+				return nil, ErrInvalidAvatarColor
+			}
+			profile.AvatarColor = strings.ToUpper(*req.AvatarColor)
+		}
+		profile.AvatarImage = nil
+		now := time.Now()
+		profile.AvatarUpdatedAt = &now
+	}
+
+	if err := s.saveProfile(profile); err != nil {
+		return nil, err
+	}
+	return s.GetMe(userID)
+}
+
+// SetAvatarImage decodes an uploaded PNG or JPEG, crops it to a centered
+// square and stores it re-encoded at 256x256, which also drops any metadata
+func (s *ProfileService) SetAvatarImage(userID uuid.UUID, data []byte) (*dto.MeResponse, error) {
+	avatar, err := processAvatar(data)
+	if err != nil {
+		return nil, err
+	}
+	profile, err := s.getProfile(userID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	profile.AvatarImage = avatar
+	profile.AvatarUpdatedAt = &now
+	if err := s.saveProfile(profile); err != nil {
+		return nil, err
+	}
+	return s.GetMe(userID)
+}
+
+// RemoveAvatarImage clears an uploaded avatar, falling back to the emoji
+func (s *ProfileService) RemoveAvatarImage(userID uuid.UUID) error {
+	return s.db.Model(&models.Profile{}).Where("user_id = ?", userID).
+		Updates(map[string]any{"avatar_image": nil, "avatar_updated_at": time.Now()}).Error
+}
+
+// GetAvatarImage returns a user's uploaded avatar as JPEG bytes
+func (s *ProfileService) GetAvatarImage(userID uuid.UUID) ([]byte, error) {
+	var profile models.Profile
+	if err := s.db.Select("avatar_image").Where("user_id = ?", userID).First(&profile).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrProfileNotFound
+		}
+		return nil, err
+	}
+	if len(profile.AvatarImage) == 0 {
+		return nil, ErrProfileNotFound
+	}
+	return profile.AvatarImage, nil
+}
+
+// GetByUsername looks up a profile case-insensitively. Users on either side
+// of a block with the viewer read as not found.
+func (s *ProfileService) GetByUsername(viewerID uuid.UUID, username string) (*dto.ProfileResponse, error) {
+	var row profileRow
+	if err := s.db.Table("profiles").
+		Select(profileRowColumns).
+		Joins("JOIN users u ON u.id = profiles.user_id AND u.deleted_at IS NULL").
+		Where("profiles.username_key = ?", strings.ToLower(strings.TrimSpace(username))).
+		Take(&row).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrProfileNotFound
+		}
+		return nil, err
+	}
+
+	if row.UserID != viewerID {
+		blocked, err := s.moderation.IsBlockedEither(viewerID, row.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if blocked {
+			return nil, ErrProfileNotFound
+		}
+	}
+	resp := profileResponse(&row.Profile, row.HasImage)
+	return &resp, nil
+}
+
+// getProfile loads the user's profile, or an unsaved empty one
+func (s *ProfileService) getProfile(userID uuid.UUID) (*models.Profile, error) {
+	profile := &models.Profile{ID: uuid.New(), UserID: userID}
+	if err := s.db.Where("user_id = ?", userID).Limit(1).Find(profile).Error; err != nil {
+		return nil, err
+	}
+	return profile, nil
+}
+
+func (s *ProfileService) saveProfile(profile *models.Profile) error {
+	err := s.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		UpdateAll: true,
+	}).Select("*").Create(profile).Error
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return ErrUsernameTaken // The only other unique column
+	}
+	return err
+}
+
+// usernameOwner resolves a username to its user, case-insensitively
+func usernameOwner(db *gorm.DB, username string) (uuid.UUID, error) {
+	var ids []uuid.UUID
+	if err := db.Model(&models.Profile{}).
+		Where("username_key = ?", strings.ToLower(strings.TrimSpace(username))).
+		Limit(1).
+		Pluck("user_id", &ids).Error; err != nil {
+		return uuid.Nil, err
+	}
+	if len(ids) == 0 {
+		return uuid.Nil, ErrProfileNotFound
+	}
+	return ids[0], nil
+}
+
+// profileSummaries loads the list view of each user's profile in one query.
+// Users without a profile are left out of the map.
+func profileSummaries(db *gorm.DB, userIDs []uuid.UUID) (map[uuid.UUID]*dto.ProfileSummary, error) {
+	summaries := make(map[uuid.UUID]*dto.ProfileSummary, len(userIDs))
+	if len(userIDs) == 0 {
+		return summaries, nil
+	}
+
+	var rows []profileRow
+	if err := db.Table("profiles").
+		Select(profileRowColumns).
+		Where("profiles.user_id IN ?", userIDs).
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	for i := range rows {
+		resp := profileResponse(&rows[i].Profile, rows[i].HasImage)
+		summaries[rows[i].UserID] = &dto.ProfileSummary{
+			Username:    resp.Username,
+			DisplayName: resp.DisplayName,
+			Avatar:      resp.Avatar,
+		}
+	}
+	return summaries, nil
+}
+
+// attachFeedProfiles fills in the profile of each feed item's author
+func attachFeedProfiles(db *gorm.DB, items []dto.FeedItem) error {
+	ids := make([]uuid.UUID, len(items))
+	for i := range items {
+		ids[i] = items[i].UserID
+	}
+	summaries, err := profileSummaries(db, ids)
+	if err != nil {
+		return err
+	}
+	for i := range items {
+		items[i].Profile = summaries[items[i].UserID]
+	}
+	return nil
+}
+
+// profileRow is a profile read without its avatar bytes
+type profileRow struct {
+	models.Profile
+	HasImage bool
+}
+
+const profileRowColumns = "profiles.user_id, profiles.username, profiles.display_name, profiles.bio, " +
+	"profiles.avatar_emoji, profiles.avatar_color, profiles.avatar_updated_at, profiles.avatar_image IS NOT NULL AS has_image"
+
+func profileResponse(profile *models.Profile, hasImage bool) dto.ProfileResponse {
+	resp := dto.ProfileResponse{
+		UserID:      profile.UserID,
+		DisplayName: profile.DisplayName,
+		Bio:         profile.Bio,
+		Avatar:      dto.Avatar{Emoji: profile.AvatarEmoji, Color: profile.AvatarColor},
+	}
+	if profile.Username != nil {
+		resp.Username = *profile.Username
+	}
+	if hasImage && profile.AvatarUpdatedAt != nil {
+		// The version busts client caches when the image changes
+		resp.Avatar.ImageURL = fmt.Sprintf("/api/avatars/%s?v=%d", profile.UserID, profile.AvatarUpdatedAt.Unix())
+	}
+	return resp
+}
+
+// processAvatar validates an upload and returns the stored avatar JPEG
+func processAvatar(data []byte) ([]byte, error) {
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || (format != "png" && format != "jpeg") {
+		return nil, ErrInvalidAvatarImage
+	}
+	// Checked before decoding so a small file can't expand into a huge bitmap
+	if cfg.Width < 1 || cfg.Height < 1 || cfg.Width > maxAvatarSourceSide || cfg.Height > maxAvatarSourceSide {
+		return nil, ErrInvalidAvatarImage
+	}
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrInvalidAvatarImage
+	}
+
+	bounds := src.Bounds()
+	side := min(bounds.Dx(), bounds.Dy())
+	crop := image.Rect(0, 0, side, side).Add(bounds.Min).
+		Add(image.Pt((bounds.Dx()-side)/2, (bounds.Dy()-side)/2))
+
+	dst := image.NewRGBA(image.Rect(0, 0, avatarSize, avatarSize))
+	draw.Draw(dst, dst.Bounds(), image.White, image.Point{}, draw.Src) // Transparent PNGs flatten onto white
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, crop, draw.Over, nil)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, dst, &jpeg.Options{Quality: avatarJPEGQuality}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func isASCII(text string) bool {
+	for _, r := range text {
+		if r >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
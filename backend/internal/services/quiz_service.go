@@ -0,0 +1,277 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/dto"
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrQuizNotFound      = errors.New("quiz not found")
+	ErrNoActiveQuiz      = errors.New("no active quiz available")
+	ErrInvalidQuizAnswer = errors.New("invalid quiz answer")
+)
+
+// quizTextWeight is how much the text analysis counts relative to a single
+// fully-weighted quiz answer when blending aesthetics.
+const quizTextWeight = 2.0
+
+type QuizService struct {
+	db *gorm.DB
+}
+
+func NewQuizService(db *gorm.DB) *QuizService {
+	return &QuizService{db: db}
+}
+
+// defaultQuiz is the launch micro-quiz from SPEC.md, seeded as version 1.
+var defaultQuiz = dto.CreateQuizSetRequest{
+	Name:   "Launch Micro-Quiz",
+	Active: true,
+	Questions: []dto.CreateQuizQuestionRequest{
+		{
+			Prompt: "Right now I feel...",
+			Options: []dto.CreateQuizOptionRequest{
+				{Label: "Energized", Emoji: "😊", Weights: map[string]float64{"energetic": 1.0, "confident": 0.5}, ScoreDelta: 8},
+				{Label: "Calm", Emoji: "😌", Weights: map[string]float64{"chill": 1.0, "peaceful": 0.5}, ScoreDelta: 2},
+				{Label: "Fired Up", Emoji: "😤", Weights: map[string]float64{"confident": 1.0, "energetic": 0.5}, ScoreDelta: 5},
+				{Label: "Low-Key", Emoji: "🥱", Weights: map[string]float64{"cozy": 1.0, "chill": 0.5}, ScoreDelta: -3},
+			},
+		},
+		{
+			Prompt: "My ideal Friday night:",
+			Options: []dto.CreateQuizOptionRequest{
+				{Label: "Party with friends", Weights: map[string]float64{"energetic": 1.0}, ScoreDelta: 4},
+				{Label: "Cozy night in", Weights: map[string]float64{"cozy": 1.0}, ScoreDelta: 1},
+				{Label: "Creative project", Weights: map[string]float64{"creative": 1.0}, ScoreDelta: 3},
+				{Label: "Adventure outdoors", Weights: map[string]float64{"adventurous": 1.0}, ScoreDelta: 4},
+			},
+		},
+		{
+			Prompt: "Pick a word:",
+			Options: []dto.CreateQuizOptionRequest{
+				{Label: "Bold", Weights: map[string]float64{"confident": 1.0}, ScoreDelta: 3},
+				{Label: "Gentle", Weights: map[string]float64{"peaceful": 0.5, "romantic": 0.5}, ScoreDelta: 1},
+				{Label: "Wild", Weights: map[string]float64{"adventurous": 0.5, "energetic": 0.5}, ScoreDelta: 3},
+				{Label: "Mysterious", Weights: map[string]float64{"mysterious": 1.0}, ScoreDelta: 0},
+			},
+		},
+	},
+}
+
+// SeedDefaults publishes the launch quiz if no quiz set exists yet
+func (s *QuizService) SeedDefaults() error {
+	var count int64
+	if err := s.db.Model(&models.QuizSet{}).Unscoped().Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+	_, err := s.CreateQuizSet(&defaultQuiz)
+	return err
+}
+
+// GetTodayQuiz returns the quiz set for the given day. Active sets rotate daily
+// in version order so every user sees the same quiz on the same date.
+func (s *QuizService) GetTodayQuiz(day time.Time) (*models.QuizSet, error) {
+	var sets []models.QuizSet
+	if err := s.db.Where("active = ?", true).Order("version ASC").Find(&sets).Error; err != nil {
+		return nil, err
+	}
+	if len(sets) == 0 {
+		return nil, ErrNoActiveQuiz
+	}
+
+	dayIndex := int(day.Truncate(24*time.Hour).Unix() / 86400)
+	set := sets[dayIndex%len(sets)]
+	return s.GetQuizSet(set.ID)
+}
+
+// GetQuizSet loads a quiz set with its questions and options in display order
+func (s *QuizService) GetQuizSet(id uuid.UUID) (*models.QuizSet, error) {
+	var set models.QuizSet
+	err := s.db.
+		Preload("Questions", func(db *gorm.DB) *gorm.DB { return db.Order("position ASC") }).
+		Preload("Questions.Options", func(db *gorm.DB) *gorm.DB { return db.Order("position ASC") }).
+		First(&set, "id = ?", id).Error
+	if err != nil {
+		return nil, ErrQuizNotFound
+	}
+	return &set, nil
+}
+
+// ListQuizSets returns all quiz versions, newest first (admin)
+func (s *QuizService) ListQuizSets() ([]models.QuizSet, error) {
+	var sets []models.QuizSet
+	if err := s.db.Order("version DESC").Find(&sets).Error; err != nil {
+		return nil, err
+	}
+	return sets, nil
+}
+
+// CreateQuizSet publishes a new quiz version with the next version number
+func (s *QuizService) CreateQuizSet(req *dto.CreateQuizSetRequest) (*models.QuizSet, error) {
+	if strings.TrimSpace(req.Name) == "" {
+		return nil, errors.New("name is required")
+	}
+	if len(req.Questions) == 0 {
+		return nil, errors.New("at least one question is required")
+	}
+
+	set := models.QuizSet{
+		ID:     uuid.New(),
+		Name:   strings.TrimSpace(req.Name),
+		Active: req.Active,
+	}
+
+	for qi, q := range req.Questions {
+		if strings.TrimSpace(q.Prompt) == "" {
+			return nil, fmt.Errorf("question %d: prompt is required", qi+1)
+		}
+		if len(q.Options) < 2 {
+			return nil, fmt.Errorf("question %d: at least two options are required", qi+1)
+		}
+
+		question := models.QuizQuestion{
+			ID:        uuid.New(),
+			QuizSetID: set.ID,
+			Position:  qi,
+			Prompt:    strings.TrimSpace(q.Prompt),
+		}
+		for oi, o := range q.Options {
+			if strings.TrimSpace(o.Label) == "" {
+				return nil, fmt.Errorf("question %d option %d: label is required", qi+1, oi+1)
+			}
+			for key := range o.Weights {
+				if _, ok := models.Aesthetics[key]; !ok {
+					return nil, fmt.Errorf("question %d option %d: unknown aesthetic %q", qi+1, oi+1, key)
+				}
+			}
+			question.Options = append(question.Options, models.QuizOption{
+				ID:         uuid.New(),
+				QuestionID: question.ID,
+				Position:   oi,
+				Label:      strings.TrimSpace(o.Label),
+				Emoji:      o.Emoji,
+				Weights:    o.Weights,
+				ScoreDelta: o.ScoreDelta,
+			})
+		}
+		set.Questions = append(set.Questions, question)
+	}
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var maxVersion int
+		if err := tx.Model(&models.QuizSet{}).Unscoped().
+			Select("COALESCE(MAX(version), 0)").
+			Scan(&maxVersion).Error; err != nil {
+			return err
+		}
+		set.Version = maxVersion + 1
+		return tx.Create(&set).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create quiz set: %w", err)
+	}
+
+	return &set, nil
+}
+
+// SetQuizSetActive adds or removes a quiz version from the daily rotation
+func (s *QuizService) SetQuizSetActive(id uuid.UUID, active bool) error {
+	result := s.db.Model(&models.QuizSet{}).Where("id = ?", id).Update("active", active)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrQuizNotFound
+	}
+	return nil
+}
+
+// ResolveAnswers validates submitted answers against a quiz set and returns the
+// chosen options. At most one answer per question is accepted; unanswered
+// questions are allowed since the quiz is optional.
+func (s *QuizService) ResolveAnswers(setID uuid.UUID, answers []dto.QuizAnswerRequest) ([]models.QuizOption, error) {
+	set, err := s.GetQuizSet(setID)
+	if err != nil {
+		return nil, err
+	}
+
+	options := make(map[uuid.UUID]models.QuizOption)
+	for _, q := range set.Questions {
+		for _, o := range q.Options {
+			options[o.ID] = o
+		}
+	}
+
+	answered := make(map[uuid.UUID]bool)
+	chosen := make([]models.QuizOption, 0, len(answers))
+	for _, a := range answers {
+		option, ok := options[a.OptionID]
+		if !ok || option.QuestionID != a.QuestionID || answered[a.QuestionID] {
+			return nil, ErrInvalidQuizAnswer
+		}
+		answered[a.QuestionID] = true
+		chosen = append(chosen, option)
+	}
+
+	return chosen, nil
+}
+
+// blendQuizAnswers combines the text analysis with quiz answer weights. The text
+// result counts as quizTextWeight toward its aesthetic; each answer adds its own
+// weights on top, and score deltas shift the vibe score.
+func blendQuizAnswers(result aiAnalysisResult, options []models.QuizOption) (aiAnalysisResult, bool) {
+	if len(options) == 0 {
+		return result, false
+	}
+
+	scores := map[string]float64{result.AestheticKey: quizTextWeight}
+	scoreDelta := 0
+	for _, o := range options {
+		for key, weight := range o.Weights {
+			scores[key] += weight
+		}
+		scoreDelta += o.ScoreDelta
+	}
+
+	best := result.AestheticKey
+	for key, score := range scores {
+		if _, ok := models.Aesthetics[key]; !ok {
+			continue
+		}
+		// Ties keep the text result; otherwise break ties by key for stable output
+		switch {
+		case score > scores[best]:
+			best = key
+		case score == scores[best] && best != result.AestheticKey && (key == result.AestheticKey || key < best):
+			best = key
+		}
+	}
+
+	blended := aiAnalysisResult{
+		AestheticKey: best,
+		VibeScore:    clampVibeScore(result.VibeScore + scoreDelta),
+		Insight:      result.Insight,
+	}
+	return blended, best != result.AestheticKey
+}
+
+// clampVibeScore keeps a score inside the 10-100 range used everywhere
+func clampVibeScore(score int) int {
+	if score < 10 {
+		return 10
+	}
+	if score > 100 {
+		return 100
+	}
+	return score
+}
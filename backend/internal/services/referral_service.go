@@ -0,0 +1,309 @@
+package services
+
+import (
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/dto"
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Referral rewards and limits
+const (
+	referralCheckInsRequired   = 3  // Invitee check-ins before the referral pays out
+	referralRewardFreezes      = 1  // Streak freezes for each side
+	maxReferralRewardsPerMonth = 10 // Per referrer, so one account can't farm freezes
+	topReferrersLimit          = 10
+)
+
+// Anti-abuse reasons recorded on rejected referrals
+const (
+	ReferralRejectDisposableEmail = "disposable_email"
+	ReferralRejectSameEmail       = "same_email"
+	ReferralRejectNoDevice        = "no_device"
+	ReferralRejectSameDevice      = "same_device"
+	ReferralRejectRewardCap       = "reward_cap"
+)
+
+// disposableEmailDomains are throwaway inbox providers that can't earn rewards
+var disposableEmailDomains = map[string]bool{
+	"mailinator.com": true, "guerrillamail.com": true, "guerrillamail.net": true, "10minutemail.com": true,
+	"tempmail.com": true, "temp-mail.org": true, "yopmail.com": true, "throwawaymail.com": true,
+	"trashmail.com": true, "getnada.com": true, "sharklasers.com": true, "dispostable.com": true,
+	"maildrop.cc": true, "fakeinbox.com": true, "mintemail.com": true, "mohmal.com": true,
+}
+
+type ReferralService struct {
+	db      *gorm.DB
+	streaks *StreakService
+	friends *FriendService
+}
+
+func NewReferralService(db *gorm.DB, streaks *StreakService, friends *FriendService) *ReferralService {
+	return &ReferralService{db: db, streaks: streaks, friends: friends}
+}
+
+// RecordSignup links a new account to the owner of the invite code it signed
+// up with and makes the two friends. Unknown codes are ignored so a typo never
+// blocks sign-up. Referrals failing an anti-abuse check still make friends but
+// are never rewarded.
+func (s *ReferralService) RecordSignup(invitee *models.User, code, deviceID, source string) error {
+	code = normalizeInviteCode(code)
+	if code == "" {
+		return nil
+	}
+	var invite models.InviteCode
+	if err := s.db.Where("code = ?", code).First(&invite).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	if invite.UserID == invitee.ID {
+		return nil
+	}
+
+	referral := models.Referral{
+		ID:         uuid.New(),
+		ReferrerID: invite.UserID,
+		InviteeID:  invitee.ID,
+		Code:       code,
+		Source:     source,
+		DeviceID:   strings.TrimSpace(deviceID),
+		Status:     models.ReferralStatusPending,
+	}
+	reason, err := s.abuseReason(invite.UserID, invitee.Email, referral.DeviceID)
+	if err != nil {
+		return err
+	}
+	if reason != "" {
+		referral.Status, referral.RejectReason = models.ReferralStatusRejected, reason
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&referral)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		if err := acceptBetween(tx, invite.UserID, invitee.ID); err != nil && !errors.Is(err, ErrFriendLimitReached) {
+			return err
+		}
+		return nil
+	})
+}
+
+// OnCheckIn rewards a pending referral once its invitee has checked in enough
+// times. Both sides get streak freezes; a referrer over the monthly cap gets
+// nothing and the referral is closed.
+func (s *ReferralService) OnCheckIn(userID uuid.UUID) error {
+	var referral models.Referral
+	err := s.db.Where("invitee_id = ? AND status = ?", userID, models.ReferralStatusPending).First(&referral).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var checkIns int64
+	if err := s.db.Model(&models.VibeCheck{}).Where("user_id = ?", userID).Count(&checkIns).Error; err != nil {
+		return err
+	}
+	if checkIns < referralCheckInsRequired {
+		return nil
+	}
+
+	var rewarded int64
+	if err := s.db.Model(&models.Referral{}).
+		Where("referrer_id = ? AND status = ? AND rewarded_at >= ?", referral.ReferrerID, models.ReferralStatusRewarded, time.Now().AddDate(0, -1, 0)).
+		Count(&rewarded).Error; err != nil {
+		return err
+	}
+
+	updates := map[string]interface{}{"status": models.ReferralStatusRewarded, "rewarded_at": time.Now()}
+	if rewarded >= maxReferralRewardsPerMonth {
+		updates = map[string]interface{}{"status": models.ReferralStatusRejected, "reject_reason": ReferralRejectRewardCap}
+	}
+	// Conditional on still pending, so concurrent check-ins can't pay out twice
+	result := s.db.Model(&models.Referral{}).
+		Where("id = ? AND status = ?", referral.ID, models.ReferralStatusPending).
+		Updates(updates)
+	if result.Error != nil || result.RowsAffected == 0 || updates["status"] != models.ReferralStatusRewarded {
+		return result.Error
+	}
+
+	for _, id := range []uuid.UUID{referral.ReferrerID, referral.InviteeID} {
+		if err := s.streaks.GrantFreezes(id, referralRewardFreezes, "referral"); err != nil {
+			log.Printf("Referral reward failed for user %s (referral %s): %v", id, referral.ID, err)
+		}
+	}
+	return nil
+}
+
+// GetReferrals returns the user's invite code and everyone they referred. The
+// caller fills in the invite URL.
+func (s *ReferralService) GetReferrals(userID uuid.UUID) (*dto.ReferralsResponse, error) {
+	invite, err := s.friends.GetInviteCode(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []struct {
+		models.Referral
+		CheckIns int
+	}
+	if err := s.db.Table("referrals AS r").
+		Select("r.*, (SELECT COUNT(*) FROM vibe_checks c WHERE c.user_id = r.invitee_id AND c.deleted_at IS NULL) AS check_ins").
+		Joins("JOIN users u ON u.id = r.invitee_id AND u.deleted_at IS NULL").
+		Where("r.referrer_id = ?", userID).
+		Where(notBlockedEither("r.invitee_id"), userID, userID).
+		Order("r.created_at DESC").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	ids := make([]uuid.UUID, len(rows))
+	for i := range rows {
+		ids[i] = rows[i].InviteeID
+	}
+	summaries, err := profileSummaries(s.db, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.ReferralsResponse{
+		InviteCodeResponse: dto.InviteCodeResponse{Code: invite.Code, CreatedAt: invite.CreatedAt.Format(time.RFC3339)},
+		CheckInsRequired:   referralCheckInsRequired,
+		RewardFreezes:      referralRewardFreezes,
+		Data:               make([]dto.ReferralResponse, 0, len(rows)),
+	}
+	for _, r := range rows {
+		// Rejections stay internal so abuse checks can't be probed
+		status := r.Status
+		if status == models.ReferralStatusRejected {
+			status = models.ReferralStatusPending
+		}
+		if r.Status == models.ReferralStatusRewarded {
+			resp.Rewarded++
+		}
+		resp.Data = append(resp.Data, dto.ReferralResponse{
+			UserID:   r.InviteeID,
+			Profile:  summaries[r.InviteeID],
+			Status:   status,
+			CheckIns: r.CheckIns,
+			JoinedAt: r.CreatedAt.Format(time.RFC3339),
+		})
+	}
+	return resp, nil
+}
+
+// GetFunnel summarizes referrals created between from and to (inclusive days)
+func (s *ReferralService) GetFunnel(from, to time.Time) (*dto.ReferralFunnelResponse, error) {
+	end := to.AddDate(0, 0, 1)
+	resp := &dto.ReferralFunnelResponse{
+		From:          from.Format("2006-01-02"),
+		To:            to.Format("2006-01-02"),
+		SignupsBy:     make(map[string]int),
+		RejectReasons: make(map[string]int),
+		TopReferrers:  []dto.ReferrerStat{},
+	}
+
+	var codes int64
+	if err := s.db.Model(&models.InviteCode{}).Where("created_at >= ? AND created_at < ?", from, end).Count(&codes).Error; err != nil {
+		return nil, err
+	}
+	resp.CodesCreated = int(codes)
+
+	var groups []struct {
+		Source       string
+		Status       string
+		RejectReason string
+		Count        int
+		Activated    int
+	}
+	if err := s.db.Table("referrals AS r").
+		Select("r.source, r.status, COALESCE(r.reject_reason, '') AS reject_reason, COUNT(*) AS count, "+
+			"COUNT(*) FILTER (WHERE EXISTS (SELECT 1 FROM vibe_checks c WHERE c.user_id = r.invitee_id AND c.deleted_at IS NULL)) AS activated").
+		Where("r.created_at >= ? AND r.created_at < ?", from, end).
+		Group("r.source, r.status, r.reject_reason").
+		Scan(&groups).Error; err != nil {
+		return nil, err
+	}
+	for _, g := range groups {
+		resp.Signups += g.Count
+		resp.SignupsBy[g.Source] += g.Count
+		resp.Activated += g.Activated
+		switch g.Status {
+		case models.ReferralStatusPending:
+			resp.Pending += g.Count
+		case models.ReferralStatusRewarded:
+			resp.Rewarded += g.Count
+		case models.ReferralStatusRejected:
+			resp.Rejected += g.Count
+			resp.RejectReasons[g.RejectReason] += g.Count
+		}
+	}
+	if resp.Signups > 0 {
+		resp.RewardRate = roundTo(float64(resp.Rewarded)/float64(resp.Signups), 3)
+	}
+
+	if err := s.db.Table("referrals").
+		Select("referrer_id AS user_id, COUNT(*) AS signups, COUNT(*) FILTER (WHERE status = ?) AS rewarded", models.ReferralStatusRewarded).
+		Where("created_at >= ? AND created_at < ?", from, end).
+		Group("referrer_id").
+		Order("signups DESC, rewarded DESC").
+		Limit(topReferrersLimit).
+		Scan(&resp.TopReferrers).Error; err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// abuseReason returns why a referral can't earn rewards, or "" if it can
+func (s *ReferralService) abuseReason(referrerID uuid.UUID, inviteeEmail, deviceID string) (string, error) {
+	_, domain, _ := strings.Cut(strings.ToLower(strings.TrimSpace(inviteeEmail)), "@")
+	if disposableEmailDomains[domain] {
+		return ReferralRejectDisposableEmail, nil
+	}
+
+	var referrer models.User
+	if err := s.db.Select("email").Where("id = ?", referrerID).First(&referrer).Error; err != nil {
+		return "", err
+	}
+	if canonicalEmail(referrer.Email) == canonicalEmail(inviteeEmail) {
+		return ReferralRejectSameEmail, nil
+	}
+
+	if deviceID == "" {
+		return ReferralRejectNoDevice, nil
+	}
+	// One device only ever earns one referral, including the device the
+	// referrer was invited on
+	var seen int64
+	if err := s.db.Model(&models.Referral{}).Where("device_id = ?", deviceID).Count(&seen).Error; err != nil {
+		return "", err
+	}
+	if seen > 0 {
+		return ReferralRejectSameDevice, nil
+	}
+	return "", nil
+}
+
+// canonicalEmail folds the aliases one inbox can sign up with: case, +tags,
+// and dots in Gmail addresses
+func canonicalEmail(email string) string {
+	local, domain, _ := strings.Cut(strings.ToLower(strings.TrimSpace(email)), "@")
+	local, _, _ = strings.Cut(local, "+")
+	if domain == "googlemail.com" {
+		domain = "gmail.com"
+	}
+	if domain == "gmail.com" {
+		local = strings.ReplaceAll(local, ".", "")
+	}
+	return local + "@" + domain
+}
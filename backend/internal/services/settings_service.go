@@ -0,0 +1,96 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/dto"
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var ErrInvalidTimezone = errors.New("invalid timezone: must be an IANA name like Europe/Istanbul")
+
+type SettingsService struct {
+	db *gorm.DB
+}
+
+func NewSettingsService(db *gorm.DB) *SettingsService {
+	return &SettingsService{db: db}
+}
+
+// GetSettings returns the user's settings, or defaults if never saved
+func (s *SettingsService) GetSettings(userID uuid.UUID) (*models.UserSettings, error) {
+	settings := defaultSettings(userID)
+	if err := s.db.Where("user_id = ?", userID).Limit(1).Find(settings).Error; err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+// UpdateSettings applies the set fields and upserts the row
+func (s *SettingsService) UpdateSettings(userID uuid.UUID, req *dto.UpdateSettingsRequest) (*models.UserSettings, error) {
+	settings, err := s.GetSettings(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Timezone != nil {
+		if _, err := time.LoadLocation(*req.Timezone); err != nil || *req.Timezone == "" || *req.Timezone == "Local" {
+			return nil, ErrInvalidTimezone
+		}
+		settings.Timezone = *req.Timezone
+	}
+	if req.NudgesEnabled != nil {
+		settings.NudgesEnabled = *req.NudgesEnabled
+	}
+	if req.ShareMoodText != nil {
+		settings.ShareMoodText = *req.ShareMoodText
+	}
+	if req.DefaultVisibility != nil {
+		if err := validVisibility(*req.DefaultVisibility); err != nil {
+			return nil, err
+		}
+		settings.DefaultVisibility = *req.DefaultVisibility
+	}
+	if req.DiscoverOptIn != nil {
+		settings.DiscoverOptIn = *req.DiscoverOptIn
+	}
+	if req.HideFromLeaderboard != nil {
+		settings.HideFromLeaderboard = *req.HideFromLeaderboard
+	}
+
+	// Select("*") so false booleans are written instead of falling back to column defaults
+	if err := s.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		UpdateAll: true,
+	}).Select("*").Create(settings).Error; err != nil {
+		return nil, err
+	}
+	return s.GetSettings(userID)
+}
+
+// Location returns the user's time zone, falling back to UTC
+func (s *SettingsService) Location(userID uuid.UUID) *time.Location {
+	settings, err := s.GetSettings(userID)
+	if err != nil {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(settings.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+func defaultSettings(userID uuid.UUID) *models.UserSettings {
+	return &models.UserSettings{
+		ID:                uuid.New(),
+		UserID:            userID,
+		Timezone:          "UTC",
+		NudgesEnabled:     true,
+		DefaultVisibility: models.VisibilityFriends,
+	}
+}
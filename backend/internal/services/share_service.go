@@ -0,0 +1,142 @@
+package services
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/dto"
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrVibeCheckNotFound = errors.New("vibe check not found")
+	ErrShareLinkNotFound = errors.New("share link not found")
+	ErrShareLinkExpired  = errors.New("share link has expired or was revoked")
+	ErrPrivateCheckShare = errors.New("private check-ins can't be shared; change its visibility first")
+)
+
+// maxShareLinkHours caps how far in the future a share link may expire (1 year)
+const maxShareLinkHours = 24 * 365
+
+type ShareService struct {
+	db *gorm.DB
+}
+
+func NewShareService(db *gorm.DB) *ShareService {
+	return &ShareService{db: db}
+}
+
+// CreateShareLink issues a new public token for a check owned by the user
+func (s *ShareService) CreateShareLink(userID, checkID uuid.UUID, req *dto.CreateShareLinkRequest) (*models.ShareLink, error) {
+	if req.ExpiresInHours < 0 || req.ExpiresInHours > maxShareLinkHours {
+		return nil, fmt.Errorf("expires_in_hours must be between 0 and %d", maxShareLinkHours)
+	}
+
+	var check models.VibeCheck
+	if err := s.db.Where("id = ? AND user_id = ?", checkID, userID).First(&check).Error; err != nil {
+		return nil, ErrVibeCheckNotFound
+	}
+	if check.Visibility == models.VisibilityPrivate {
+		return nil, ErrPrivateCheckShare
+	}
+
+	token, err := generateShareToken()
+	if err != nil {
+		return nil, err
+	}
+
+	link := models.ShareLink{
+		ID:           uuid.New(),
+		Token:        token,
+		VibeCheckID:  check.ID,
+		UserID:       userID,
+		ShowMoodText: req.ShowMoodText,
+	}
+	if req.ExpiresInHours > 0 {
+		expiresAt := time.Now().Add(time.Duration(req.ExpiresInHours) * time.Hour)
+		link.ExpiresAt = &expiresAt
+	}
+
+	if err := s.db.Create(&link).Error; err != nil {
+		return nil, fmt.Errorf("failed to create share link: %w", err)
+	}
+
+	return &link, nil
+}
+
+// ListShareLinks returns every link the user created for a check, with view counts
+func (s *ShareService) ListShareLinks(userID, checkID uuid.UUID) ([]models.ShareLink, error) {
+	var links []models.ShareLink
+	if err := s.db.Where("vibe_check_id = ? AND user_id = ?", checkID, userID).
+		Order("created_at DESC").
+		Find(&links).Error; err != nil {
+		return nil, err
+	}
+	return links, nil
+}
+
+// RevokeShareLink permanently disables a link owned by the user
+func (s *ShareService) RevokeShareLink(userID, linkID uuid.UUID) error {
+	result := s.db.Model(&models.ShareLink{}).
+		Where("id = ? AND user_id = ?", linkID, userID).
+		Update("revoked", true)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrShareLinkNotFound
+	}
+	return nil
+}
+
+// ResolveShareLink looks up a live link and its check. When countView is set the
+// link's view counter is incremented atomically. A check made private after
+// sharing stops resolving, and the returned link only shows mood text when the
+// check doesn't hide it.
+func (s *ShareService) ResolveShareLink(token string, countView bool) (*models.ShareLink, *models.VibeCheck, error) {
+	var link models.ShareLink
+	if err := s.db.Where("token = ?", token).First(&link).Error; err != nil {
+		return nil, nil, ErrShareLinkNotFound
+	}
+
+	if link.Revoked || (link.ExpiresAt != nil && time.Now().After(*link.ExpiresAt)) {
+		return nil, nil, ErrShareLinkExpired
+	}
+
+	var check models.VibeCheck
+	if err := s.db.Table("vibe_checks AS c").
+		Where("c.id = ?", link.VibeCheckID).
+		Where(readableBy("c", checkReader{ViaShareLink: true})).
+		Take(&check).Error; err != nil {
+		// The check was deleted or made private after sharing
+		return nil, nil, ErrShareLinkNotFound
+	}
+	link.ShowMoodText = link.ShowMoodText && moodTextReadable(&check, uuid.Nil)
+
+	if countView {
+		now := time.Now()
+		s.db.Model(&models.ShareLink{}).
+			Where("id = ?", link.ID).
+			Updates(map[string]interface{}{
+				"view_count":     gorm.Expr("view_count + 1"),
+				"last_viewed_at": now,
+			})
+		link.ViewCount++
+		link.LastViewedAt = &now
+	}
+
+	return &link, &check, nil
+}
+
+func generateShareToken() (string, error) {
+	rawBytes := make([]byte, 16)
+	if _, err := rand.Read(rawBytes); err != nil {
+		return "", fmt.Errorf("failed to generate random bytes: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(rawBytes), nil
+}
@@ -0,0 +1,530 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/dto"
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrNoRepairableStreak = errors.New("no broken streak to repair, or the repair window has closed")
+	ErrNoRepairTokens     = errors.New("no streak repairs available")
+)
+
+// Streak freeze and repair economy
+const (
+	freezeEarnEvery    = 7              // A freeze is earned every N check-ins
+	maxEarnedFreezes   = 2              // Earning stops at this inventory size
+	premiumFreezeGrant = 2              // Freezes granted per premium purchase/renewal
+	maxFreezes         = 5              // Hard cap, including premium grants
+	repairEarnEvery    = 30             // A repair is earned every N check-ins
+	repairWindow       = 48 * time.Hour // How long after a break a repair is allowed
+)
+
+// streakAuditBatch is how many users are audited between progress reports
+const streakAuditBatch = 100
+
+// Streak event types
+const (
+	StreakEventFreezeEarned    = "freeze_earned"
+	StreakEventFreezeGranted   = "freeze_granted"
+	StreakEventFreezeUsed      = "freeze_used"
+	StreakEventRepairEarned    = "repair_earned"
+	StreakEventRepairPurchased = "repair_purchased"
+	StreakEventRepairUsed      = "repair_used"
+)
+
+type StreakService struct {
+	db *gorm.DB
+}
+
+func NewStreakService(db *gorm.DB) *StreakService {
+	return &StreakService{db: db}
+}
+
+// RecordCheckIn advances the user's streak for a check-in on the given day.
+// Missed days are covered by freezes when enough are available; otherwise the
+// streak resets and the lost length stays repairable for repairWindow.
+func (s *StreakService) RecordCheckIn(tx *gorm.DB, userID uuid.UUID, today time.Time) (*models.VibeStreak, error) {
+	streak, err := lockStreak(tx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []models.StreakEvent
+	gap := daysBetween(streak.LastCheckDate, today)
+	if streak.TotalChecks == 0 {
+		// First check-in ever; a new row has no meaningful LastCheckDate
+		gap = 1
+	}
+	streak.TotalChecks++
+
+	switch {
+	case gap == 1:
+		streak.CurrentStreak++
+	case gap > 1 && gap-1 <= streak.FreezesAvailable:
+		// Freezes cover every missed day, so the streak carries on
+		for d := 1; d < gap; d++ {
+			events = append(events, models.StreakEvent{
+				UserID:    userID,
+				Type:      StreakEventFreezeUsed,
+				EventDate: streak.LastCheckDate.AddDate(0, 0, d),
+			})
+		}
+		streak.FreezesAvailable -= gap - 1
+		streak.CurrentStreak++
+	case gap > 1:
+		// Streak broke at the end of the first missed day
+		brokeAt := streak.LastCheckDate.AddDate(0, 0, 2)
+		deadline := brokeAt.Add(repairWindow)
+		if streak.CurrentStreak > 1 && time.Now().Before(deadline) {
+			streak.RepairableStreak = streak.CurrentStreak
+			streak.RepairDeadline = &deadline
+		} else {
+			streak.RepairableStreak = 0
+			streak.RepairDeadline = nil
+		}
+		streak.CurrentStreak = 1
+	}
+
+	if streak.CurrentStreak > streak.LongestStreak {
+		streak.LongestStreak = streak.CurrentStreak
+	}
+	if gap > 0 {
+		streak.LastCheckDate = today
+	}
+
+	// Earn freezes and repairs for consistency
+	if streak.TotalChecks%freezeEarnEvery == 0 && streak.FreezesAvailable < maxEarnedFreezes {
+		streak.FreezesAvailable++
+		events = append(events, models.StreakEvent{
+			UserID:    userID,
+			Type:      StreakEventFreezeEarned,
+			EventDate: today,
+			Note:      fmt.Sprintf("%d check-ins", streak.TotalChecks),
+		})
+	}
+	if streak.TotalChecks%repairEarnEvery == 0 {
+		streak.RepairsAvailable++
+		events = append(events, models.StreakEvent{
+			UserID:    userID,
+			Type:      StreakEventRepairEarned,
+			EventDate: today,
+			Note:      fmt.Sprintf("%d check-ins", streak.TotalChecks),
+		})
+	}
+
+	if err := tx.Save(streak).Error; err != nil {
+		return nil, err
+	}
+	if len(events) > 0 {
+		if err := tx.Create(&events).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	return streak, nil
+}
+
+// RepairStreak spends a repair token to restore the streak lost at the last break.
+// The restored length is added to whatever streak has been built since.
+func (s *StreakService) RepairStreak(userID uuid.UUID) (*models.VibeStreak, error) {
+	var streak *models.VibeStreak
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		if streak, err = lockStreak(tx, userID); err != nil {
+			return err
+		}
+		if streak.RepairableStreak == 0 || streak.RepairDeadline == nil || time.Now().After(*streak.RepairDeadline) {
+			return ErrNoRepairableStreak
+		}
+		if streak.RepairsAvailable == 0 {
+			return ErrNoRepairTokens
+		}
+
+		restored := streak.RepairableStreak
+		streak.CurrentStreak += restored
+		if streak.CurrentStreak > streak.LongestStreak {
+			streak.LongestStreak = streak.CurrentStreak
+		}
+		streak.RepairsAvailable--
+		streak.RepairableStreak = 0
+		streak.RepairDeadline = nil
+
+		if err := tx.Save(streak).Error; err != nil {
+			return err
+		}
+		return tx.Create(&models.StreakEvent{
+			UserID:    userID,
+			Type:      StreakEventRepairUsed,
+			EventDate: time.Now().Truncate(24 * time.Hour),
+			Note:      fmt.Sprintf("restored %d days", restored),
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return streak, nil
+}
+
+// GrantPremiumFreezes tops up freezes for a premium purchase or renewal, up to
+// maxFreezes. Webhook retries are ignored by matching the store transaction ID,
+// which is recorded even when the cap leaves nothing to grant.
+func (s *StreakService) GrantPremiumFreezes(userID uuid.UUID, transactionID string) error {
+	if transactionID == "" {
+		return s.grantFreezes(userID, premiumFreezeGrant, "premium", false)
+	}
+	return s.grantFreezes(userID, premiumFreezeGrant, "premium "+transactionID, true)
+}
+
+// GrantFreezes tops up freezes from a reward, up to maxFreezes. The source is
+// recorded in the event note.
+func (s *StreakService) GrantFreezes(userID uuid.UUID, count int, source string) error {
+	return s.grantFreezes(userID, count, source, false)
+}
+
+// grantFreezes records grants as "source: +N". With once set, a source that
+// was already granted is skipped.
+func (s *StreakService) grantFreezes(userID uuid.UUID, count int, source string, once bool) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		// Locking first serializes concurrent deliveries of the same grant
+		streak, err := lockStreak(tx, userID)
+		if err != nil {
+			return err
+		}
+		if once {
+			var seen int64
+			if err := tx.Model(&models.StreakEvent{}).
+				Where("user_id = ? AND type = ? AND starts_with(note, ?)", userID, StreakEventFreezeGranted, source+":").
+				Count(&seen).Error; err != nil {
+				return err
+			}
+			if seen > 0 {
+				return nil
+			}
+		}
+
+		granted := min(count, maxFreezes-streak.FreezesAvailable)
+		if granted <= 0 && !once {
+			return nil
+		}
+		granted = max(granted, 0)
+		streak.FreezesAvailable += granted
+
+		if err := tx.Save(streak).Error; err != nil {
+			return err
+		}
+		return tx.Create(&models.StreakEvent{
+			UserID:    userID,
+			Type:      StreakEventFreezeGranted,
+			EventDate: time.Now().Truncate(24 * time.Hour),
+			Note:      fmt.Sprintf("%s: +%d", source, granted),
+		}).Error
+	})
+}
+
+// AddPurchasedRepair credits a streak repair bought in the store. Webhook
+// retries are ignored by matching the store transaction ID; without one, every
+// delivery is credited.
+func (s *StreakService) AddPurchasedRepair(userID uuid.UUID, transactionID string) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		// Locking first serializes concurrent deliveries of the same purchase
+		streak, err := lockStreak(tx, userID)
+		if err != nil {
+			return err
+		}
+		if transactionID != "" {
+			var credited int64
+			if err := tx.Model(&models.StreakEvent{}).
+				Where("user_id = ? AND type = ? AND note = ?", userID, StreakEventRepairPurchased, transactionID).
+				Count(&credited).Error; err != nil {
+				return err
+			}
+			if credited > 0 {
+				return nil
+			}
+		}
+
+		streak.RepairsAvailable++
+		if err := tx.Save(streak).Error; err != nil {
+			return err
+		}
+		return tx.Create(&models.StreakEvent{
+			UserID:    userID,
+			Type:      StreakEventRepairPurchased,
+			EventDate: time.Now().Truncate(24 * time.Hour),
+			Note:      transactionID,
+		}).Error
+	})
+}
+
+// GetStreakEvents returns the most recent freeze/repair history for stats
+func (s *StreakService) GetStreakEvents(userID uuid.UUID, limit int) ([]models.StreakEvent, error) {
+	var events []models.StreakEvent
+	if err := s.db.Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&events).Error; err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// StreakAuditOptions scopes an audit. An empty UserIDs audits every user with
+// a check or a streak row.
+type StreakAuditOptions struct {
+	UserIDs  []uuid.UUID
+	Fix      bool
+	Progress func(done, total int) // Optional, called after each batch
+}
+
+// AuditStreaks recomputes streaks from vibe_checks history and reports every
+// user whose stored VibeStreak disagrees, optionally fixing it in place.
+func (s *StreakService) AuditStreaks(opts StreakAuditOptions) (*dto.StreakAuditResponse, error) {
+	userIDs := opts.UserIDs
+	if len(userIDs) == 0 {
+		if err := s.db.Raw(`
+			SELECT user_id FROM vibe_checks WHERE user_id IS NOT NULL AND deleted_at IS NULL
+			UNION
+			SELECT user_id FROM vibe_streaks WHERE deleted_at IS NULL
+			ORDER BY user_id`).Scan(&userIDs).Error; err != nil {
+			return nil, fmt.Errorf("failed to list users: %w", err)
+		}
+	}
+
+	report := &dto.StreakAuditResponse{Mismatches: []dto.StreakMismatch{}}
+	for i, userID := range userIDs {
+		mismatch, err := s.auditUser(userID, opts.Fix)
+		if err != nil {
+			return nil, fmt.Errorf("failed to audit user %s: %w", userID, err)
+		}
+		report.Audited++
+		if mismatch != nil {
+			report.Mismatches = append(report.Mismatches, *mismatch)
+			if mismatch.Fixed {
+				report.Fixed++
+			}
+		}
+		if opts.Progress != nil && ((i+1)%streakAuditBatch == 0 || i+1 == len(userIDs)) {
+			opts.Progress(i+1, len(userIDs))
+		}
+	}
+
+	return report, nil
+}
+
+// auditUser compares one user's stored streak with the recomputed one
+func (s *StreakService) auditUser(userID uuid.UUID, fix bool) (*dto.StreakMismatch, error) {
+	expected, err := s.recomputeStreak(userID, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+
+	var stored models.VibeStreak
+	if err := s.db.Where("user_id = ?", userID).Limit(1).Find(&stored).Error; err != nil {
+		return nil, err
+	}
+	if streakSnapshot(&stored) == streakSnapshot(expected) {
+		return nil, nil
+	}
+
+	mismatch := &dto.StreakMismatch{
+		UserID:   userID,
+		Stored:   streakSnapshot(&stored),
+		Expected: streakSnapshot(expected),
+	}
+	if !fix {
+		return mismatch, nil
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		streak, err := lockStreak(tx, userID)
+		if err != nil {
+			return err
+		}
+		return tx.Model(streak).Updates(map[string]interface{}{
+			"current_streak":  expected.CurrentStreak,
+			"longest_streak":  expected.LongestStreak,
+			"total_checks":    expected.TotalChecks,
+			"last_check_date": expected.LastCheckDate,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	mismatch.Fixed = true
+	return mismatch, nil
+}
+
+// recomputeStreak replays check dates in order. Gaps covered by freeze_used
+// events continue the streak, and repair_used events restore the run lost at
+// the preceding break, mirroring RecordCheckIn and RepairStreak. A non-zero
+// through stops the replay at the end of that day.
+func (s *StreakService) recomputeStreak(userID uuid.UUID, through time.Time) (*models.VibeStreak, error) {
+	checkQuery := s.db.Select("check_date").Where("user_id = ?", userID)
+	eventQuery := s.db.Where("user_id = ? AND type IN ?", userID, []string{StreakEventFreezeUsed, StreakEventRepairUsed})
+	if !through.IsZero() {
+		checkQuery = checkQuery.Where("check_date <= ?", through)
+		eventQuery = eventQuery.Where("event_date <= ?", through)
+	}
+
+	var checks []models.VibeCheck
+	if err := checkQuery.Order("check_date ASC").Find(&checks).Error; err != nil {
+		return nil, err
+	}
+
+	var events []models.StreakEvent
+	if err := eventQuery.Order("event_date ASC, created_at ASC").Find(&events).Error; err != nil {
+		return nil, err
+	}
+
+	frozen := make(map[string]bool)
+	var repairs []time.Time
+	for _, e := range events {
+		if e.Type == StreakEventFreezeUsed {
+			frozen[e.EventDate.Format("2006-01-02")] = true
+		} else {
+			repairs = append(repairs, e.EventDate)
+		}
+	}
+
+	streak := &models.VibeStreak{UserID: userID, TotalChecks: len(checks)}
+	lost := 0
+	for _, check := range checks {
+		// Apply repairs made before this check-in
+		for len(repairs) > 0 && daysBetween(repairs[0], check.CheckDate) > 0 {
+			streak.CurrentStreak += lost
+			lost = 0
+			repairs = repairs[1:]
+		}
+
+		switch gap := daysBetween(streak.LastCheckDate, check.CheckDate); {
+		case streak.CurrentStreak == 0:
+			streak.CurrentStreak = 1
+		case gap == 1 || gapFrozen(frozen, streak.LastCheckDate, gap):
+			streak.CurrentStreak++
+		case gap > 1:
+			lost = streak.CurrentStreak
+			streak.CurrentStreak = 1
+		}
+		streak.LastCheckDate = check.CheckDate
+		if streak.CurrentStreak > streak.LongestStreak {
+			streak.LongestStreak = streak.CurrentStreak
+		}
+	}
+	for range repairs {
+		streak.CurrentStreak += lost
+		lost = 0
+	}
+	if streak.CurrentStreak > streak.LongestStreak {
+		streak.LongestStreak = streak.CurrentStreak
+	}
+
+	return streak, nil
+}
+
+// streakAt rebuilds the user's streak as it stood when day (UTC) ended, from
+// check-ins and streak events alone. Like the live leaderboard, the current
+// run only counts if the freezes held then could cover every day missed since
+// the last check-in.
+func (s *StreakService) streakAt(userID uuid.UUID, day time.Time) (*models.VibeStreak, error) {
+	streak, err := s.recomputeStreak(userID, day)
+	if err != nil {
+		return nil, err
+	}
+	missed := daysBetween(streak.LastCheckDate, day)
+	if streak.TotalChecks == 0 || missed == 0 {
+		return streak, nil
+	}
+
+	// Freezes are spent at the next check-in, so only events logged before
+	// the day ended count towards the balance
+	var events []models.StreakEvent
+	if err := s.db.Select("type", "note").
+		Where("user_id = ? AND type IN ? AND created_at < ?", userID,
+			[]string{StreakEventFreezeEarned, StreakEventFreezeGranted, StreakEventFreezeUsed}, day.AddDate(0, 0, 1)).
+		Find(&events).Error; err != nil {
+		return nil, err
+	}
+	if missed > freezesHeld(events) {
+		streak.CurrentStreak = 0
+	}
+	return streak, nil
+}
+
+// freezesHeld tallies a freeze balance from its events: one for each freeze
+// earned or used, and the "+N" recorded in a grant's note
+func freezesHeld(events []models.StreakEvent) int {
+	held := 0
+	for _, e := range events {
+		switch e.Type {
+		case StreakEventFreezeEarned:
+			held++
+		case StreakEventFreezeUsed:
+			held--
+		case StreakEventFreezeGranted:
+			if i := strings.LastIndex(e.Note, "+"); i >= 0 {
+				n, _ := strconv.Atoi(e.Note[i+1:])
+				held += n
+			}
+		}
+	}
+	return held
+}
+
+// gapFrozen reports whether every missed day after from was covered by a freeze
+func gapFrozen(frozen map[string]bool, from time.Time, gap int) bool {
+	if gap <= 1 {
+		return false
+	}
+	for d := 1; d < gap; d++ {
+		if !frozen[from.AddDate(0, 0, d).Format("2006-01-02")] {
+			return false
+		}
+	}
+	return true
+}
+
+func streakSnapshot(streak *models.VibeStreak) dto.StreakSnapshot {
+	snapshot := dto.StreakSnapshot{
+		CurrentStreak: streak.CurrentStreak,
+		LongestStreak: streak.LongestStreak,
+		TotalChecks:   streak.TotalChecks,
+	}
+	if streak.TotalChecks > 0 {
+		snapshot.LastCheckDate = streak.LastCheckDate.Format("2006-01-02")
+	}
+	return snapshot
+}
+
+// lockStreak loads the user's streak row with SELECT ... FOR UPDATE, creating an
+// empty row first if needed, so concurrent writers serialize on the same row.
+func lockStreak(tx *gorm.DB, userID uuid.UUID) (*models.VibeStreak, error) {
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&models.VibeStreak{UserID: userID}).Error; err != nil {
+		return nil, err
+	}
+
+	var streak models.VibeStreak
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("user_id = ?", userID).
+		First(&streak).Error; err != nil {
+		return nil, err
+	}
+	return &streak, nil
+}
+
+// daysBetween counts calendar days from a to b (both truncated to dates)
+func daysBetween(a, b time.Time) int {
+	a = time.Date(a.Year(), a.Month(), a.Day(), 0, 0, 0, 0, time.UTC)
+	b = time.Date(b.Year(), b.Month(), b.Day(), 0, 0, 0, 0, time.UTC)
+	return int(b.Sub(a).Hours() / 24)
+}
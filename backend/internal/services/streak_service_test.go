@@ -0,0 +1,89 @@
+package services
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/models"
+)
+
+func TestAddPurchasedRepairOncePerTransaction(t *testing.T) {
+	db := testDB(t)
+	streaks := NewStreakService(db)
+	user := createTestUser(t, db)
+
+	repairs := func() int {
+		t.Helper()
+		var streak models.VibeStreak
+		if err := db.Where("user_id = ?", user.ID).First(&streak).Error; err != nil {
+			t.Fatalf("load streak: %v", err)
+		}
+		return streak.RepairsAvailable
+	}
+
+	// Retried deliveries of one purchase racing each other
+	const deliveries = 6
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	for range deliveries {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			if err := streaks.AddPurchasedRepair(user.ID, "txn-1"); err != nil {
+				t.Errorf("AddPurchasedRepair: %v", err)
+			}
+		}()
+	}
+	close(start)
+	wg.Wait()
+	if got := repairs(); got != 1 {
+		t.Fatalf("RepairsAvailable after %d deliveries of one purchase = %d, want 1", deliveries, got)
+	}
+
+	if err := streaks.AddPurchasedRepair(user.ID, "txn-2"); err != nil {
+		t.Fatalf("AddPurchasedRepair: %v", err)
+	}
+	if got := repairs(); got != 2 {
+		t.Errorf("RepairsAvailable after a second purchase = %d, want 2", got)
+	}
+
+	// Without a transaction ID there's nothing to match, so nothing is dropped
+	for range 2 {
+		if err := streaks.AddPurchasedRepair(user.ID, ""); err != nil {
+			t.Fatalf("AddPurchasedRepair: %v", err)
+		}
+	}
+	if got := repairs(); got != 4 {
+		t.Errorf("RepairsAvailable after two purchases without IDs = %d, want 4", got)
+	}
+}
+
+func TestFreezesHeld(t *testing.T) {
+	tests := []struct {
+		name   string
+		events []models.StreakEvent
+		want   int
+	}{
+		{"no events", nil, 0},
+		{"earned and used", []models.StreakEvent{
+			{Type: StreakEventFreezeEarned},
+			{Type: StreakEventFreezeEarned},
+			{Type: StreakEventFreezeUsed},
+		}, 1},
+		{"grants add what their note records", []models.StreakEvent{
+			{Type: StreakEventFreezeGranted, Note: "premium: +2"},
+			{Type: StreakEventFreezeGranted, Note: "premium txn-9: +0"},
+			{Type: StreakEventFreezeGranted, Note: "referral: +1"},
+			{Type: StreakEventFreezeUsed},
+		}, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := freezesHeld(tt.events); got != tt.want {
+				t.Errorf("freezesHeld = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
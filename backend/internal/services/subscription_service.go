@@ -11,11 +11,13 @@ import (
 )
 
 type SubscriptionService struct {
-	db *gorm.DB
+	db              *gorm.DB
+	streaks         *StreakService
+	repairProductID string // One-time store product that buys a streak repair
 }
 
-func NewSubscriptionService(db *gorm.DB) *SubscriptionService {
-	return &SubscriptionService{db: db}
+func NewSubscriptionService(db *gorm.DB, streaks *StreakService, repairProductID string) *SubscriptionService {
+	return &SubscriptionService{db: db, streaks: streaks, repairProductID: repairProductID}
 }
 
 func (s *SubscriptionService) HandleWebhookEvent(event *dto.RevenueCatEvent) error {
@@ -28,6 +30,8 @@ func (s *SubscriptionService) HandleWebhookEvent(event *dto.RevenueCatEvent) err
 		return s.handleCancellation(event)
 	case "EXPIRATION":
 		return s.handleExpiration(event)
+	case "NON_RENEWING_PURCHASE":
+		return s.handleNonRenewingPurchase(event)
 	default:
 		// Log unknown event type but don't fail
 		return nil
@@ -50,7 +54,15 @@ func (s *SubscriptionService) handleInitialPurchase(event *dto.RevenueCatEvent)
 		sub.UserID = user.ID
 	}
 
-	return s.db.Create(&sub).Error
+	if err := s.db.Create(&sub).Error; err != nil {
+		return err
+	}
+
+	// Premium includes streak freezes
+	if sub.UserID != uuid.Nil {
+		return s.streaks.GrantPremiumFreezes(sub.UserID, eventTransactionID(event))
+	}
+	return nil
 }
 
 func (s *SubscriptionService) handleRenewal(event *dto.RevenueCatEvent) error {
@@ -59,11 +71,19 @@ func (s *SubscriptionService) handleRenewal(event *dto.RevenueCatEvent) error {
 		return fmt.Errorf("subscription not found for renewal: %w", err)
 	}
 
-	return s.db.Model(&sub).Updates(map[string]interface{}{
+	if err := s.db.Model(&sub).Updates(map[string]interface{}{
 		"status":               "active",
 		"current_period_end":   msToTime(event.ExpirationAtMs),
 		"current_period_start": msToTime(event.PurchasedAtMs),
-	}).Error
+	}).Error; err != nil {
+		return err
+	}
+
+	// Each renewal tops up premium streak freezes
+	if sub.UserID != uuid.Nil {
+		return s.streaks.GrantPremiumFreezes(sub.UserID, eventTransactionID(event))
+	}
+	return nil
 }
 
 func (s *SubscriptionService) handleCancellation(event *dto.RevenueCatEvent) error {
@@ -78,6 +98,29 @@ func (s *SubscriptionService) handleExpiration(event *dto.RevenueCatEvent) error
 		Update("status", "expired").Error
 }
 
+// handleNonRenewingPurchase credits one-time purchases such as streak repairs
+func (s *SubscriptionService) handleNonRenewingPurchase(event *dto.RevenueCatEvent) error {
+	if s.repairProductID == "" || event.ProductID != s.repairProductID {
+		return nil
+	}
+
+	var user models.User
+	if err := s.db.Where("id = ?", event.AppUserID).First(&user).Error; err != nil {
+		return fmt.Errorf("user not found for streak repair purchase: %w", err)
+	}
+
+	return s.streaks.AddPurchasedRepair(user.ID, eventTransactionID(event))
+}
+
 func msToTime(ms int64) time.Time {
 	return time.Unix(ms/1000, (ms%1000)*int64(time.Millisecond))
 }
+
+// eventTransactionID identifies the purchase behind an event, falling back to
+// the event's own ID, which RevenueCat keeps across retries
+func eventTransactionID(event *dto.RevenueCatEvent) string {
+	if event.TransactionID != "" {
+		return event.TransactionID
+	}
+	return event.ID
+}
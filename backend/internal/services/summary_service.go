@@ -0,0 +1,327 @@
+package services
+
+import (
+	"errors"
+	"log"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrInvalidSummaryPeriod = errors.New("invalid period: must be week, month or year")
+	ErrNoSummaryData        = errors.New("no vibe checks in this period")
+)
+
+const (
+	summaryTopWords   = 5
+	summaryTagEffects = 3
+	summaryMinTagUses = 2 // A quiz answer needs this many uses before its effect is reported
+)
+
+type SummaryService struct {
+	db *gorm.DB
+}
+
+func NewSummaryService(db *gorm.DB) *SummaryService {
+	return &SummaryService{db: db}
+}
+
+// GetSummary returns the summary for the period containing day, or the most
+// recently closed period when day is zero. Closed periods are generated once
+// and persisted; the current period is built live.
+func (s *SummaryService) GetSummary(userID uuid.UUID, period string, day time.Time) (*models.VibeSummary, error) {
+	if day.IsZero() {
+		currentStart, _, err := periodBounds(period, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		day = currentStart.AddDate(0, 0, -1)
+	}
+
+	start, end, err := periodBounds(period, day)
+	if err != nil {
+		return nil, err
+	}
+
+	today := time.Now().Truncate(24 * time.Hour)
+	if !end.Before(today) {
+		summary, err := s.buildSummary(userID, period, start, end)
+		if err != nil {
+			return nil, err
+		}
+		summary.InProgress = true
+		return summary, nil
+	}
+
+	var summary models.VibeSummary
+	err = s.db.Where("user_id = ? AND period = ? AND period_start = ?", userID, period, start).
+		First(&summary).Error
+	if err == nil {
+		return &summary, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	return s.persistSummary(userID, period, start, end)
+}
+
+// GenerateClosedSummaries persists summaries for the most recently closed
+// week, month and year for every user who checked in during them.
+func (s *SummaryService) GenerateClosedSummaries(now time.Time) (int, error) {
+	generated := 0
+	for _, period := range []string{models.SummaryPeriodWeek, models.SummaryPeriodMonth, models.SummaryPeriodYear} {
+		currentStart, _, _ := periodBounds(period, now)
+		start, end, _ := periodBounds(period, currentStart.AddDate(0, 0, -1))
+
+		var userIDs []uuid.UUID
+		if err := s.db.Model(&models.VibeCheck{}).
+			Distinct("user_id").
+			Where("user_id IS NOT NULL AND check_date >= ? AND check_date <= ?", start, end).
+			Where("user_id NOT IN (?)", s.db.Model(&models.VibeSummary{}).
+				Select("user_id").
+				Where("period = ? AND period_start = ?", period, start)).
+			Pluck("user_id", &userIDs).Error; err != nil {
+			return generated, err
+		}
+
+		for _, userID := range userIDs {
+			if _, err := s.persistSummary(userID, period, start, end); err != nil {
+				log.Printf("Summary generation failed for user %s (%s %s): %v", userID, period, start.Format("2006-01-02"), err)
+				continue
+			}
+			generated++
+		}
+	}
+	return generated, nil
+}
+
+// StartScheduler generates closed-period summaries every interval until stop is closed
+func (s *SummaryService) StartScheduler(interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if n, err := s.GenerateClosedSummaries(time.Now()); err != nil {
+			log.Printf("Summary generation failed: %v", err)
+		} else if n > 0 {
+			log.Printf("Generated %d vibe summaries", n)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-stop:
+			return
+		}
+	}
+}
+
+func (s *SummaryService) persistSummary(userID uuid.UUID, period string, start, end time.Time) (*models.VibeSummary, error) {
+	summary, err := s.buildSummary(userID, period, start, end)
+	if err != nil {
+		return nil, err
+	}
+	summary.ID = uuid.New()
+
+	// A concurrent request may have persisted it first; keep whichever landed
+	if err := s.db.Clauses(clause.OnConflict{DoNothing: true}).Create(summary).Error; err != nil {
+		return nil, err
+	}
+	var stored models.VibeSummary
+	if err := s.db.Where("user_id = ? AND period = ? AND period_start = ?", userID, period, start).
+		First(&stored).Error; err != nil {
+		return nil, err
+	}
+	return &stored, nil
+}
+
+// buildSummary computes a summary from the checks between start and end (inclusive)
+func (s *SummaryService) buildSummary(userID uuid.UUID, period string, start, end time.Time) (*models.VibeSummary, error) {
+	var checks []models.VibeCheck
+	if err := s.db.Where("user_id = ? AND check_date >= ? AND check_date <= ?", userID, start, end).
+		Order("check_date ASC").
+		Find(&checks).Error; err != nil {
+		return nil, err
+	}
+	if len(checks) == 0 {
+		return nil, ErrNoSummaryData
+	}
+
+	summary := &models.VibeSummary{
+		UserID:      userID,
+		Period:      period,
+		PeriodStart: start,
+		PeriodEnd:   end,
+		CheckCount:  len(checks),
+	}
+
+	total := 0
+	aestheticCounts := make(map[string]int)
+	moodTexts := make([]string, 0, len(checks))
+	best, worst := checks[0], checks[0]
+	run := 0
+	var prevDate time.Time
+	for _, check := range checks {
+		total += check.VibeScore
+		aestheticCounts[check.Aesthetic]++
+		moodTexts = append(moodTexts, check.MoodText)
+
+		if check.VibeScore > best.VibeScore {
+			best = check
+		}
+		if check.VibeScore < worst.VibeScore {
+			worst = check
+		}
+
+		if run > 0 && daysBetween(prevDate, check.CheckDate) == 1 {
+			run++
+		} else {
+			run = 1
+		}
+		prevDate = check.CheckDate
+		if run > summary.LongestStreak {
+			summary.LongestStreak = run
+		}
+	}
+	summary.AvgScore = roundTo(float64(total)/float64(len(checks)), 1)
+	summary.BestDay = summaryDay(best)
+	summary.WorstDay = summaryDay(worst)
+
+	// Dominant aesthetic; ties go to the most recent occurrence
+	for i := len(checks) - 1; i >= 0; i-- {
+		if aestheticCounts[checks[i].Aesthetic] > aestheticCounts[summary.DominantAesthetic] {
+			summary.DominantAesthetic = checks[i].Aesthetic
+			summary.DominantEmoji = checks[i].Emoji
+		}
+	}
+
+	// Previous period average for comparison
+	prevStart, prevEnd, _ := periodBounds(period, start.AddDate(0, 0, -1))
+	var prev struct {
+		Count int
+		Avg   float64
+	}
+	if err := s.db.Model(&models.VibeCheck{}).
+		Select("COUNT(*) AS count, COALESCE(AVG(vibe_score), 0) AS avg").
+		Where("user_id = ? AND check_date >= ? AND check_date <= ?", userID, prevStart, prevEnd).
+		Scan(&prev).Error; err != nil {
+		return nil, err
+	}
+	if prev.Count > 0 {
+		prevAvg := roundTo(prev.Avg, 1)
+		summary.PrevAvgScore = &prevAvg
+	}
+
+	summary.TopWords = topWords(moodTexts, summaryTopWords)
+
+	tagEffects, err := s.tagEffects(userID, start, end, summary.AvgScore)
+	if err != nil {
+		return nil, err
+	}
+	summary.TagEffects = tagEffects
+
+	return summary, nil
+}
+
+// tagEffects measures how check-ins with each micro-quiz answer scored
+// relative to the period average. Quiz answers are the only user-chosen
+// labels on a check, so they serve as its tags.
+func (s *SummaryService) tagEffects(userID uuid.UUID, start, end time.Time, periodAvg float64) ([]models.TagEffect, error) {
+	var rows []struct {
+		Tag      string
+		Checks   int
+		AvgScore float64
+	}
+	if err := s.db.Raw(`
+		SELECT o.label AS tag, COUNT(*) AS checks, AVG(c.vibe_score) AS avg_score
+		FROM quiz_answers a
+		JOIN quiz_options o ON o.id = a.option_id
+		JOIN vibe_checks c ON c.id = a.vibe_check_id
+		WHERE c.user_id = ? AND c.deleted_at IS NULL AND c.check_date >= ? AND c.check_date <= ?
+		GROUP BY o.label
+		HAVING COUNT(*) >= ?`,
+		userID, start, end, summaryMinTagUses,
+	).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	effects := make([]models.TagEffect, 0, len(rows))
+	for _, r := range rows {
+		effects = append(effects, models.TagEffect{
+			Tag:      r.Tag,
+			Checks:   r.Checks,
+			AvgScore: roundTo(r.AvgScore, 1),
+			Delta:    roundTo(r.AvgScore-periodAvg, 1),
+		})
+	}
+	sort.Slice(effects, func(i, j int) bool {
+		return math.Abs(effects[i].Delta) > math.Abs(effects[j].Delta)
+	})
+	if len(effects) > summaryTagEffects {
+		effects = effects[:summaryTagEffects]
+	}
+	return effects, nil
+}
+
+// periodBounds returns the first and last day of the period containing day.
+// Weeks run Monday to Sunday.
+func periodBounds(period string, day time.Time) (time.Time, time.Time, error) {
+	d := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+	switch period {
+	case models.SummaryPeriodWeek:
+		offset := (int(d.Weekday()) + 6) % 7 // Days since Monday
+		start := d.AddDate(0, 0, -offset)
+		return start, start.AddDate(0, 0, 6), nil
+	case models.SummaryPeriodMonth:
+		start := time.Date(d.Year(), d.Month(), 1, 0, 0, 0, 0, time.UTC)
+		return start, start.AddDate(0, 1, -1), nil
+	case models.SummaryPeriodYear:
+		start := time.Date(d.Year(), 1, 1, 0, 0, 0, 0, time.UTC)
+		return start, start.AddDate(1, 0, -1), nil
+	}
+	return time.Time{}, time.Time{}, ErrInvalidSummaryPeriod
+}
+
+func summaryDay(check models.VibeCheck) *models.SummaryDay {
+	return &models.SummaryDay{
+		Date:      check.CheckDate.Format("2006-01-02"),
+		VibeScore: check.VibeScore,
+		Aesthetic: check.Aesthetic,
+		Emoji:     check.Emoji,
+	}
+}
+
+// topWords returns the n most frequent words across texts, ties broken alphabetically
+func topWords(texts []string, n int) []models.WordCount {
+	counts := make(map[string]int)
+	for _, text := range texts {
+		for _, word := range tokenizeMood(text) {
+			counts[word]++
+		}
+	}
+
+	words := make([]models.WordCount, 0, len(counts))
+	for word, count := range counts {
+		words = append(words, models.WordCount{Word: word, Count: count})
+	}
+	sort.Slice(words, func(i, j int) bool {
+		if words[i].Count != words[j].Count {
+			return words[i].Count > words[j].Count
+		}
+		return words[i].Word < words[j].Word
+	})
+	if len(words) > n {
+		words = words[:n]
+	}
+	return words
+}
+
+func roundTo(v float64, places int) float64 {
+	p := math.Pow(10, float64(places))
+	return math.Round(v*p) / p
+}
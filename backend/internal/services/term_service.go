@@ -0,0 +1,276 @@
+package services
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/dto"
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var ErrUnsupportedLanguage = errors.New("unsupported language: must be en, tr or es")
+
+const (
+	termBackfillBatch = 500 // Checks indexed per batch when catching up old history
+	maxTopTerms       = 100
+)
+
+// stopWordsByLang are skipped when reporting words. The index keeps every
+// token, so the language is only applied at query time.
+var stopWordsByLang = map[string]map[string]bool{
+	"en": wordSet(`a about after again all also am an and any are as at be because been being bit
+		but by can could day did do does doing don't feel feeling feels felt for from get got had has
+		have having he her here him his how i i'm im if in into is it it's its just kind like me more
+		much my myself no not now of off on one or our out really she so some still than that the their
+		them then there they this to today too up very was we were what when which who will with would
+		you your`),
+	"tr": wordSet(`acaba ama ancak bana bazı be ben beni benim bile bir biraz birçok biz bu bugün
+		bunu çok çünkü da daha de değil diye en gibi hem hep her hiç için ile ise kadar ki kim mi mı
+		mu mü na ne neden o olan olarak oldu olduğu olsun on ona onu sanki şey şu tüm ve veya ya yani`),
+	"es": wordSet(`a al algo como con de del el ella en es esta está estoy estar fue ha hay la las
+		le lo los me mi muy más no nos o para pero por que qué se sin sobre su también te tengo un una
+		uno y ya yo hoy`),
+}
+
+func wordSet(words string) map[string]bool {
+	set := make(map[string]bool)
+	for _, w := range strings.Fields(words) {
+		set[w] = true
+	}
+	return set
+}
+
+type TermService struct {
+	db *gorm.DB
+}
+
+func NewTermService(db *gorm.DB) *TermService {
+	return &TermService{db: db}
+}
+
+// IndexCheck (re)writes the term rows for a single check-in
+func (s *TermService) IndexCheck(check *models.VibeCheck) error {
+	if check.UserID == nil {
+		return nil
+	}
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		return writeTerms(tx, []models.VibeCheck{*check})
+	})
+}
+
+// writeTerms replaces the checks' term rows and marks them indexed. Checks
+// whose text has no tokens are marked too, so the backfill doesn't pick them
+// up again.
+func writeTerms(tx *gorm.DB, checks []models.VibeCheck) error {
+	ids := make([]uuid.UUID, len(checks))
+	var terms []models.MoodTerm
+	for i := range checks {
+		ids[i] = checks[i].ID
+		terms = append(terms, extractTerms(&checks[i])...)
+	}
+
+	if err := tx.Where("vibe_check_id IN ?", ids).Delete(&models.MoodTerm{}).Error; err != nil {
+		return err
+	}
+	if len(terms) > 0 {
+		if err := tx.CreateInBatches(&terms, 1000).Error; err != nil {
+			return err
+		}
+	}
+	return tx.Model(&models.VibeCheck{}).
+		Where("id IN ?", ids).
+		UpdateColumn("terms_indexed_at", time.Now()).Error
+}
+
+// GetTopTerms returns the most frequent words and two-word phrases in the
+// user's mood text between from and to (zero times leave the range open).
+// An empty lang filters stop words from every supported language.
+func (s *TermService) GetTopTerms(userID uuid.UUID, from, to time.Time, lang string, limit int) (*dto.TopTermsResponse, error) {
+	stopWords, err := stopWordsFor(lang)
+	if err != nil {
+		return nil, err
+	}
+	if limit < 1 || limit > maxTopTerms {
+		limit = 20
+	}
+
+	if err := s.backfill(userID); err != nil {
+		return nil, err
+	}
+
+	base := func() *gorm.DB {
+		q := s.db.Model(&models.MoodTerm{}).
+			Select("term, SUM(occurrences) AS count, COUNT(*) AS entries, AVG(vibe_score) AS avg_score").
+			Where("user_id = ?", userID)
+		if !from.IsZero() {
+			q = q.Where("check_date >= ?", from)
+		}
+		if !to.IsZero() {
+			q = q.Where("check_date <= ?", to)
+		}
+		return q.Group("term").Order("count DESC, entries DESC, term ASC").Limit(limit)
+	}
+
+	resp := &dto.TopTermsResponse{Language: lang, Words: []dto.TermStat{}, Phrases: []dto.TermStat{}}
+	if resp.Language == "" {
+		resp.Language = "all"
+	}
+	if !from.IsZero() {
+		resp.From = from.Format("2006-01-02")
+	}
+	if !to.IsZero() {
+		resp.To = to.Format("2006-01-02")
+	}
+
+	if err := base().
+		Where("n = 1 AND LENGTH(term) >= 3 AND term NOT IN ?", stopWords).
+		Scan(&resp.Words).Error; err != nil {
+		return nil, err
+	}
+	// Phrases made only of stop words ("i am") carry no meaning; one is fine ("not happy")
+	if err := base().
+		Where("n = 2 AND NOT (SPLIT_PART(term, ' ', 1) IN ? AND SPLIT_PART(term, ' ', 2) IN ?)", stopWords, stopWords).
+		Scan(&resp.Phrases).Error; err != nil {
+		return nil, err
+	}
+
+	for _, list := range [][]dto.TermStat{resp.Words, resp.Phrases} {
+		for i := range list {
+			list[i].AvgScore = roundTo(list[i].AvgScore, 1)
+		}
+	}
+	return resp, nil
+}
+
+// backfill indexes checks created before the term index existed, or whose
+// indexing failed at check-in
+func (s *TermService) backfill(userID uuid.UUID) error {
+	for {
+		var checks []models.VibeCheck
+		if err := s.db.Select("id", "user_id", "check_date", "vibe_score", "mood_text").
+			Where("user_id = ? AND mood_text != '' AND terms_indexed_at IS NULL", userID).
+			Limit(termBackfillBatch).
+			Find(&checks).Error; err != nil {
+			return err
+		}
+		if len(checks) == 0 {
+			return nil
+		}
+
+		// Every check in the batch gets marked, so each pass makes progress
+		if err := s.db.Transaction(func(tx *gorm.DB) error {
+			return writeTerms(tx, checks)
+		}); err != nil {
+			return err
+		}
+		if len(checks) < termBackfillBatch {
+			return nil
+		}
+	}
+}
+
+// extractTerms counts the unigrams and bigrams of a check's mood text
+func extractTerms(check *models.VibeCheck) []models.MoodTerm {
+	tokens := tokenize(check.MoodText)
+	counts := make(map[string]int)
+	order := make([]string, 0, len(tokens)*2)
+	add := func(term string) {
+		if counts[term] == 0 {
+			order = append(order, term)
+		}
+		counts[term]++
+	}
+	for i, tok := range tokens {
+		add(tok)
+		if i > 0 {
+			add(tokens[i-1] + " " + tok)
+		}
+	}
+
+	terms := make([]models.MoodTerm, 0, len(order))
+	for _, term := range order {
+		if len(term) > 100 {
+			continue
+		}
+		n := 1
+		if strings.Contains(term, " ") {
+			n = 2
+		}
+		terms = append(terms, models.MoodTerm{
+			ID:          uuid.New(),
+			UserID:      *check.UserID,
+			CheckDate:   check.CheckDate,
+			VibeCheckID: check.ID,
+			VibeScore:   check.VibeScore,
+			Term:        term,
+			N:           n,
+			Occurrences: counts[term],
+		})
+	}
+	return terms
+}
+
+// tokenize lowercases text and splits it into words of letters and
+// apostrophes, keeping every word of two letters or more.
+func tokenize(text string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '\'' && r != '’'
+	})
+	words := make([]string, 0, len(fields))
+	for _, f := range fields {
+		f = strings.Trim(strings.ReplaceAll(f, "’", "'"), "'")
+		if len([]rune(f)) >= 2 {
+			words = append(words, f)
+		}
+	}
+	return words
+}
+
+// tokenizeMood returns the meaningful words of a mood text: no stop words in
+// any supported language and nothing shorter than three letters.
+func tokenizeMood(text string) []string {
+	var words []string
+	for _, w := range tokenize(text) {
+		if len([]rune(w)) >= 3 && !isStopWord(w) {
+			words = append(words, w)
+		}
+	}
+	return words
+}
+
+func isStopWord(word string) bool {
+	for _, set := range stopWordsByLang {
+		if set[word] {
+			return true
+		}
+	}
+	return false
+}
+
+// stopWordsFor returns the stop word list for a language, or every language's when empty
+func stopWordsFor(lang string) ([]string, error) {
+	var sets []map[string]bool
+	if lang == "" {
+		for _, set := range stopWordsByLang {
+			sets = append(sets, set)
+		}
+	} else if set, ok := stopWordsByLang[lang]; ok {
+		sets = append(sets, set)
+	} else {
+		return nil, ErrUnsupportedLanguage
+	}
+
+	var words []string
+	for _, set := range sets {
+		for w := range set {
+			words = append(words, w)
+		}
+	}
+	sort.Strings(words)
+	return words, nil
+}
@@ -0,0 +1,48 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/models"
+)
+
+func TestBackfillPassesTokenlessChecks(t *testing.T) {
+	db := testDB(t)
+	terms := NewTermService(db)
+	user := createTestUser(t, db)
+
+	// A full batch of emoji-only history ahead of the one check with words,
+	// none of it indexed yet
+	today := time.Now().Truncate(24 * time.Hour)
+	checks := make([]models.VibeCheck, 0, termBackfillBatch+1)
+	for i := range termBackfillBatch {
+		checks = append(checks, models.VibeCheck{UserID: &user.ID, MoodText: "🌧️🌧️", CheckDate: today.AddDate(0, 0, -i)})
+	}
+	checks = append(checks, models.VibeCheck{UserID: &user.ID, MoodText: "rainy but hopeful", CheckDate: today.AddDate(0, 0, -termBackfillBatch)})
+	if err := db.CreateInBatches(&checks, 100).Error; err != nil {
+		t.Fatalf("create checks: %v", err)
+	}
+
+	resp, err := terms.GetTopTerms(user.ID, time.Time{}, time.Time{}, "en", 20)
+	if err != nil {
+		t.Fatalf("GetTopTerms: %v", err)
+	}
+	found := false
+	for _, w := range resp.Words {
+		found = found || w.Term == "hopeful"
+	}
+	if !found {
+		t.Errorf("words %+v are missing the older check's \"hopeful\"", resp.Words)
+	}
+
+	var unindexed int64
+	if err := db.Model(&models.VibeCheck{}).
+		Where("user_id = ? AND terms_indexed_at IS NULL", user.ID).
+		Count(&unindexed).Error; err != nil {
+		t.Fatalf("count unindexed: %v", err)
+	}
+	if unindexed != 0 {
+		t.Errorf("%d checks left unindexed after the backfill, want 0", unindexed)
+	}
+}
@@ -0,0 +1,65 @@
+package services
+
+import (
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/database"
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// Tests that depend on Postgres constraints, locking or SQL run against the
+// database in TEST_DATABASE_URL. They're skipped locally when it isn't set,
+// and fail in CI, which always provides one. Point it at a disposable
+// database: the schema is migrated on first use and rows are left behind,
+// each test working on users of its own.
+
+var (
+	testDBOnce sync.Once
+	testDBConn *gorm.DB
+	testDBErr  error
+)
+
+// testDB returns the migrated test database, skipping the test without one
+// outside CI
+func testDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		if os.Getenv("CI") != "" {
+			t.Fatal("TEST_DATABASE_URL must be set in CI")
+		}
+		t.Skip("TEST_DATABASE_URL not set")
+	}
+
+	testDBOnce.Do(func() {
+		testDBConn, testDBErr = gorm.Open(postgres.Open(dsn), &gorm.Config{
+			Logger:         logger.Default.LogMode(logger.Silent),
+			TranslateError: true, // Same as database.Connect, services rely on gorm.ErrDuplicatedKey
+		})
+		if testDBErr != nil {
+			return
+		}
+		database.DB = testDBConn
+		testDBErr = database.Migrate()
+	})
+	if testDBErr != nil {
+		t.Fatalf("test database: %v", testDBErr)
+	}
+	return testDBConn
+}
+
+// createTestUser inserts a user with a unique email
+func createTestUser(t *testing.T, db *gorm.DB) *models.User {
+	t.Helper()
+	user := &models.User{ID: uuid.New(), Email: uuid.NewString() + "@test.vibecheck", Password: "x"}
+	if err := db.Create(user).Error; err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	return user
+}
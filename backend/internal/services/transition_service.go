@@ -0,0 +1,199 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/dto"
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// Transition thresholds
+const (
+	transitionTopLimit     = 5
+	minTransitionsFrom     = 3 // Moves out of an aesthetic before "you most often..." is claimed
+	transitionStatementMax = 3
+)
+
+// transitionDay is the last check-in on one local day
+type transitionDay struct {
+	date      time.Time
+	aesthetic string
+}
+
+// GetTransitions builds the user's transition matrix between the aesthetics of
+// consecutive local days. Days are taken in the user's time zone so a late
+// check-in is ordered by the day the user lived it; a missed day breaks the chain.
+func (s *PatternService) GetTransitions(userID uuid.UUID) (*dto.TransitionsResponse, error) {
+	loc := s.settings.Location(userID)
+
+	var checks []models.VibeCheck
+	if err := s.db.Select("created_at", "aesthetic").
+		Where("user_id = ? AND aesthetic != ''", userID).
+		Order("created_at ASC").
+		Find(&checks).Error; err != nil {
+		return nil, err
+	}
+
+	days := localDays(checks, loc)
+	resp := &dto.TransitionsResponse{
+		Timezone:   loc.String(),
+		Days:       len(days),
+		Matrix:     make(map[string]map[string]int),
+		Top:        []dto.AestheticTransition{},
+		Stays:      []dto.AestheticStay{},
+		Statements: []string{},
+	}
+
+	outgoing := make(map[string]int)
+	for i := 1; i < len(days); i++ {
+		if daysBetween(days[i-1].date, days[i].date) != 1 {
+			continue
+		}
+		from, to := days[i-1].aesthetic, days[i].aesthetic
+		if resp.Matrix[from] == nil {
+			resp.Matrix[from] = make(map[string]int)
+		}
+		resp.Matrix[from][to]++
+		outgoing[from]++
+		resp.Transitions++
+	}
+
+	for from, row := range resp.Matrix {
+		for to, count := range row {
+			if from == to {
+				continue
+			}
+			resp.Top = append(resp.Top, dto.AestheticTransition{
+				From:        from,
+				To:          to,
+				Count:       count,
+				Probability: roundTo(float64(count)/float64(outgoing[from]), 2),
+			})
+		}
+	}
+	sort.Slice(resp.Top, func(i, j int) bool {
+		a, b := resp.Top[i], resp.Top[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		if a.From != b.From {
+			return a.From < b.From
+		}
+		return a.To < b.To
+	})
+	if len(resp.Top) > transitionTopLimit {
+		resp.Top = resp.Top[:transitionTopLimit]
+	}
+
+	resp.Stays = aestheticStays(days, resp.Matrix)
+	resp.Statements = transitionStatements(resp.Stays)
+	return resp, nil
+}
+
+// localDays collapses check-ins (oldest first) to one per local day, keeping
+// the latest where a time zone change puts two on the same day.
+func localDays(checks []models.VibeCheck, loc *time.Location) []transitionDay {
+	days := make([]transitionDay, 0, len(checks))
+	for _, check := range checks {
+		local := check.CreatedAt.In(loc)
+		date := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
+		if n := len(days); n > 0 && days[n-1].date.Equal(date) {
+			days[n-1].aesthetic = check.Aesthetic
+			continue
+		}
+		days = append(days, transitionDay{date: date, aesthetic: check.Aesthetic})
+	}
+	return days
+}
+
+// aestheticStays measures runs of consecutive days in the same aesthetic and
+// the aesthetic most often moved into when a run ends.
+func aestheticStays(days []transitionDay, matrix map[string]map[string]int) []dto.AestheticStay {
+	type runStats struct{ runs, days, longest int }
+	stats := make(map[string]*runStats)
+	for i := 0; i < len(days); {
+		j := i + 1
+		for j < len(days) && days[j].aesthetic == days[i].aesthetic && daysBetween(days[j-1].date, days[j].date) == 1 {
+			j++
+		}
+		st := stats[days[i].aesthetic]
+		if st == nil {
+			st = &runStats{}
+			stats[days[i].aesthetic] = st
+		}
+		st.runs++
+		st.days += j - i
+		st.longest = max(st.longest, j-i)
+		i = j
+	}
+
+	stays := make([]dto.AestheticStay, 0, len(stats))
+	for aesthetic, st := range stats {
+		stay := dto.AestheticStay{
+			Aesthetic:   aesthetic,
+			Runs:        st.runs,
+			AvgDays:     roundTo(float64(st.days)/float64(st.runs), 1),
+			LongestDays: st.longest,
+		}
+		if next, count, moves := nextAesthetic(matrix[aesthetic], aesthetic); count > 0 {
+			stay.NextMost = next
+			stay.Moves = moves
+			stay.NextShare = roundTo(float64(count)/float64(moves), 2)
+		}
+		stays = append(stays, stay)
+	}
+	sort.Slice(stays, func(i, j int) bool {
+		if stays[i].Runs != stays[j].Runs {
+			return stays[i].Runs > stays[j].Runs
+		}
+		return stays[i].Aesthetic < stays[j].Aesthetic
+	})
+	return stays
+}
+
+// nextAesthetic returns the most common different aesthetic in a matrix row,
+// its count, and the total moves to any different aesthetic.
+func nextAesthetic(row map[string]int, from string) (string, int, int) {
+	best, bestCount, moves := "", 0, 0
+	for to, count := range row {
+		if to == from {
+			continue
+		}
+		moves += count
+		if count > bestCount || (count == bestCount && to < best) {
+			best, bestCount = to, count
+		}
+	}
+	return best, bestCount, moves
+}
+
+// transitionStatements phrases the clearest habits, e.g. "After a Melancholy
+// Soul day, you most often bounce into Cozy Mode (60% of the time)"
+func transitionStatements(stays []dto.AestheticStay) []string {
+	statements := []string{}
+	for _, stay := range stays {
+		if len(statements) == transitionStatementMax {
+			break
+		}
+		if stay.NextMost == "" || stay.Moves < minTransitionsFrom {
+			continue
+		}
+		statements = append(statements, fmt.Sprintf("After a %s day, you most often bounce into %s (%.0f%% of the time)",
+			stay.Aesthetic, stay.NextMost, stay.NextShare*100))
+	}
+
+	// The longest-lasting aesthetic, when it reliably lasts more than a day
+	var sticky *dto.AestheticStay
+	for i := range stays {
+		if stays[i].Runs >= minTransitionsFrom && stays[i].AvgDays > 1 && (sticky == nil || stays[i].AvgDays > sticky.AvgDays) {
+			sticky = &stays[i]
+		}
+	}
+	if sticky != nil {
+		statements = append(statements, fmt.Sprintf("When you're %s, it usually lasts %.1f days", sticky.Aesthetic, sticky.AvgDays))
+	}
+	return statements
+}
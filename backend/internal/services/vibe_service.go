@@ -4,24 +4,41 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"log"
 	"math"
 	"net/http"
 	"strings"
 	"time"
 
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/dto"
 	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/models"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
+var (
+	ErrAlreadyCheckedIn   = errors.New("already checked in today")
+	ErrInvalidTrendBucket = errors.New("invalid bucket: must be day, week or month")
+	ErrInvalidTrendRange  = errors.New("invalid trend range")
+)
+
 type VibeService struct {
 	db        *gorm.DB
 	openaiKey string
+	quiz      *QuizService
+	streaks      *StreakService
+	achievements *AchievementService
+	forecasts    *ForecastService
+	anomalies    *AnomalyService
+	terms        *TermService
+	settings     *SettingsService
+	discover     *DiscoverService
+	referrals    *ReferralService
 }
 
-func NewVibeService(db *gorm.DB, openaiKey string) *VibeService {
-	return &VibeService{db: db, openaiKey: openaiKey}
+func NewVibeService(db *gorm.DB, openaiKey string, quiz *QuizService, streaks *StreakService, achievements *AchievementService, forecasts *ForecastService, anomalies *AnomalyService, terms *TermService, settings *SettingsService, discover *DiscoverService, referrals *ReferralService) *VibeService {
+	return &VibeService{db: db, openaiKey: openaiKey, quiz: quiz, streaks: streaks, achievements: achievements, forecasts: forecasts, anomalies: anomalies, terms: terms, settings: settings, discover: discover, referrals: referrals}
 }
 
 // OpenAI API types
@@ -333,19 +350,54 @@ var insightTemplates = map[string][]string{
 	},
 }
 
-// CreateVibeCheck creates a new vibe check-in
-func (s *VibeService) CreateVibeCheck(userID uuid.UUID, moodText string) (*models.VibeCheck, error) {
+// CreateVibeCheck creates a new vibe check-in, optionally blending in micro-quiz answers
+func (s *VibeService) CreateVibeCheck(userID uuid.UUID, req *dto.CreateVibeCheckRequest) (*models.VibeCheck, error) {
 	today := time.Now().Truncate(24 * time.Hour)
+	moodText := req.MoodText
 
-	// Check if already checked in today
+	// Fast path; the (user_id, check_date) unique index is the real guard
 	var existing models.VibeCheck
 	if err := s.db.Where("user_id = ? AND check_date = ?", userID, today).First(&existing).Error; err == nil {
-		return nil, errors.New("already checked in today")
+		return nil, ErrAlreadyCheckedIn
+	}
+
+	// Who can see the check: the request's choice, else the account defaults
+	settings, err := s.settings.GetSettings(userID)
+	if err != nil {
+		return nil, err
+	}
+	visibility, hideMoodText := settings.DefaultVisibility, !settings.ShareMoodText
+	if req.Visibility != "" {
+		if err := validVisibility(req.Visibility); err != nil {
+			return nil, err
+		}
+		visibility = req.Visibility
+	}
+	if req.HideMoodText != nil {
+		hideMoodText = *req.HideMoodText
+	}
+
+	// Resolve quiz answers before spending an AI call on an invalid request
+	var quizOptions []models.QuizOption
+	if req.QuizSetID != nil && len(req.QuizAnswers) > 0 {
+		options, err := s.quiz.ResolveAnswers(*req.QuizSetID, req.QuizAnswers)
+		if err != nil {
+			return nil, err
+		}
+		quizOptions = options
 	}
 
 	// Analyze mood — try AI first, fall back to keywords
 	result := s.analyzeWithAI(moodText)
 
+	// Blend quiz weights with the text analysis
+	blended, changed := blendQuizAnswers(result, quizOptions)
+	if changed {
+		// The text insight no longer matches the aesthetic, so regenerate it
+		blended.Insight = s.generateInsight(blended.AestheticKey, blended.VibeScore, moodText)
+	}
+	result = blended
+
 	aesthetic := models.Aesthetics[result.AestheticKey]
 
 	check := &models.VibeCheck{
@@ -359,14 +411,69 @@ func (s *VibeService) CreateVibeCheck(userID uuid.UUID, moodText string) (*model
 		Emoji:          aesthetic.Emoji,
 		Insight:        result.Insight,
 		CheckDate:      today,
+		Visibility:     visibility,
+		HideMoodText:   hideMoodText,
+	}
+	if len(quizOptions) > 0 {
+		check.QuizSetID = req.QuizSetID
 	}
 
-	if err := s.db.Create(check).Error; err != nil {
+	// The check, its quiz answers and the streak update commit together, so a
+	// concurrent duplicate request can never double-count the streak
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(check).Error; err != nil {
+			if errors.Is(err, gorm.ErrDuplicatedKey) {
+				return ErrAlreadyCheckedIn
+			}
+			return err
+		}
+		for _, o := range quizOptions {
+			answer := models.QuizAnswer{
+				VibeCheckID: check.ID,
+				QuizSetID:   *req.QuizSetID,
+				QuestionID:  o.QuestionID,
+				OptionID:    o.ID,
+			}
+			if err := tx.Create(&answer).Error; err != nil {
+				return err
+			}
+		}
+		if _, err := s.streaks.RecordCheckIn(tx, userID, today); err != nil {
+			return fmt.Errorf("failed to update streak: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	// Update streak
-	s.updateStreak(userID, today)
+	// Badges never block a check-in; anything missed unlocks on the next one
+	unlocked, err := s.achievements.Evaluate(userID, &check.ID)
+	if err != nil {
+		log.Printf("Achievement evaluation failed for user %s: %v", userID, err)
+	}
+	check.NewAchievements = unlocked
+
+	if err := s.forecasts.Resolve(userID, check); err != nil {
+		log.Printf("Forecast resolution failed for user %s: %v", userID, err)
+	}
+
+	nudge, err := s.anomalies.Observe(userID, check)
+	if err != nil {
+		log.Printf("Anomaly check failed for user %s: %v", userID, err)
+	}
+	check.Nudge = nudge
+
+	// Unindexed checks are picked up by the backfill on the next word query
+	if err := s.terms.IndexCheck(check); err != nil {
+		log.Printf("Mood term indexing failed for user %s: %v", userID, err)
+	}
+	if err := s.discover.Publish(check); err != nil {
+		log.Printf("Discover publishing failed for user %s: %v", userID, err)
+	}
+	if err := s.referrals.OnCheckIn(userID); err != nil {
+		log.Printf("Referral check-in failed for user %s: %v", userID, err)
+	}
 
 	return check, nil
 }
@@ -714,37 +821,9 @@ func (s *VibeService) generateInsight(aestheticKey string, vibeScore int, moodTe
 	return templates[templateIndex]
 }
 
-// updateStreak updates user's streak
-func (s *VibeService) updateStreak(userID uuid.UUID, today time.Time) {
-	var streak models.VibeStreak
-	if err := s.db.Where("user_id = ?", userID).First(&streak).Error; err != nil {
-		// Create new streak
-		streak = models.VibeStreak{
-			UserID:        userID,
-			CurrentStreak: 1,
-			LongestStreak: 1,
-			TotalChecks:   1,
-			LastCheckDate: today,
-		}
-		s.db.Create(&streak)
-		return
-	}
-
-	yesterday := today.AddDate(0, 0, -1)
-	streak.TotalChecks++
-
-	if streak.LastCheckDate.Equal(yesterday) {
-		streak.CurrentStreak++
-	} else if !streak.LastCheckDate.Equal(today) {
-		streak.CurrentStreak = 1
-	}
-
-	if streak.CurrentStreak > streak.LongestStreak {
-		streak.LongestStreak = streak.CurrentStreak
-	}
-	streak.LastCheckDate = today
-
-	s.db.Save(&streak)
+// RepairStreak restores a recently broken streak using a repair token
+func (s *VibeService) RepairStreak(userID uuid.UUID) (*models.VibeStreak, error) {
+	return s.streaks.RepairStreak(userID)
 }
 
 // GetTodayCheck returns today's check-in
@@ -757,6 +836,41 @@ func (s *VibeService) GetTodayCheck(userID uuid.UUID) (*models.VibeCheck, error)
 	return &check, nil
 }
 
+// GetVibeCheck returns a single check owned by the user
+func (s *VibeService) GetVibeCheck(userID, checkID uuid.UUID) (*models.VibeCheck, error) {
+	var check models.VibeCheck
+	if err := s.db.Where("id = ? AND user_id = ?", checkID, userID).First(&check).Error; err != nil {
+		return nil, err
+	}
+	return &check, nil
+}
+
+// UpdateVisibility changes who can see one of the user's checks
+func (s *VibeService) UpdateVisibility(userID, checkID uuid.UUID, req *dto.UpdateCheckVisibilityRequest) (*models.VibeCheck, error) {
+	check, err := s.GetVibeCheck(userID, checkID)
+	if err != nil {
+		return nil, ErrVibeCheckNotFound
+	}
+
+	updates := map[string]interface{}{}
+	if req.Visibility != nil {
+		if err := validVisibility(*req.Visibility); err != nil {
+			return nil, err
+		}
+		updates["visibility"] = *req.Visibility
+	}
+	if req.HideMoodText != nil {
+		updates["hide_mood_text"] = *req.HideMoodText
+	}
+	if len(updates) == 0 {
+		return check, nil
+	}
+	if err := s.db.Model(check).Updates(updates).Error; err != nil {
+		return nil, err
+	}
+	return check, nil
+}
+
 // GetVibeHistory returns user's vibe history
 func (s *VibeService) GetVibeHistory(userID uuid.UUID, limit, offset int) ([]models.VibeCheck, int64, error) {
 	var checks []models.VibeCheck
@@ -822,6 +936,19 @@ func (s *VibeService) GetVibeStats(userID uuid.UUID) (map[string]interface{}, er
 		moodDistribution[d.Aesthetic] = d.Count
 	}
 
+	// Freeze inventory and recent freeze/repair usage
+	streakHistory, err := s.streaks.GetStreakEvents(userID, 20)
+	if err != nil {
+		return nil, err
+	}
+
+	var repairDeadline *time.Time
+	repairableStreak := 0
+	if streak.RepairDeadline != nil && time.Now().Before(*streak.RepairDeadline) {
+		repairDeadline = streak.RepairDeadline
+		repairableStreak = streak.RepairableStreak
+	}
+
 	return map[string]interface{}{
 		"current_streak":    streak.CurrentStreak,
 		"longest_streak":    streak.LongestStreak,
@@ -830,58 +957,141 @@ func (s *VibeService) GetVibeStats(userID uuid.UUID) (map[string]interface{}, er
 		"top_aesthetic":     topAesthetic,
 		"last_7_avg":        last7Avg,
 		"mood_distribution": moodDistribution,
+		"streak_freezes": map[string]interface{}{
+			"available":      streak.FreezesAvailable,
+			"next_freeze_in": freezeEarnEvery - streak.TotalChecks%freezeEarnEvery,
+		},
+		"streak_repair": map[string]interface{}{
+			"available":         streak.RepairsAvailable,
+			"repairable_streak": repairableStreak,
+			"repair_deadline":   repairDeadline,
+		},
+		"streak_history": streakHistory,
 	}, nil
 }
 
-// GetVibeTrend retrieves vibe data for the last N days, filling gaps with zero values
-func (s *VibeService) GetVibeTrend(userID uuid.UUID, days int) ([]map[string]interface{}, error) {
-	if days > 30 {
-		days = 30
+// Trend buckets
+const (
+	TrendBucketDay   = "day"
+	TrendBucketWeek  = "week"
+	TrendBucketMonth = "month"
+)
+
+// Longest trend range per bucket, in days
+var maxTrendDays = map[string]int{
+	TrendBucketDay:   366,
+	TrendBucketWeek:  366 * 5,
+	TrendBucketMonth: 366 * 5,
+}
+
+// GetVibeTrend aggregates vibe scores between from and to (inclusive) into day,
+// week or month buckets. Buckets without a check have null scores.
+func (s *VibeService) GetVibeTrend(userID uuid.UUID, from, to time.Time, bucket string) ([]dto.VibeTrendItem, error) {
+	maxDays, ok := maxTrendDays[bucket]
+	if !ok {
+		return nil, ErrInvalidTrendBucket
 	}
-	if days < 1 {
-		days = 7
+	from, to = from.Truncate(24*time.Hour), to.Truncate(24*time.Hour)
+	if to.Before(from) || daysBetween(from, to) >= maxDays {
+		return nil, fmt.Errorf("%w: up to %d days for %s buckets", ErrInvalidTrendRange, maxDays, bucket)
 	}
 
-	endDate := time.Now().Truncate(24 * time.Hour)
-	startDate := endDate.AddDate(0, 0, -(days - 1))
-
-	// Query vibe checks in the date range
 	var checks []models.VibeCheck
-	if err := s.db.Where("user_id = ? AND check_date >= ? AND check_date <= ?", userID, startDate, endDate).
+	if err := s.db.Select("check_date", "vibe_score", "aesthetic", "emoji").
+		Where("user_id = ? AND check_date >= ? AND check_date <= ?", userID, from, to).
 		Order("check_date ASC").
 		Find(&checks).Error; err != nil {
 		return nil, err
 	}
 
-	// Build a map of existing data by date string
-	existingData := make(map[string]models.VibeCheck)
+	// Bucket start for a date; weeks start on Monday like summaries
+	bucketStart := func(d time.Time) time.Time {
+		if bucket == TrendBucketDay {
+			return d
+		}
+		period := models.SummaryPeriodWeek
+		if bucket == TrendBucketMonth {
+			period = models.SummaryPeriodMonth
+		}
+		start, _, _ := periodBounds(period, d)
+		return start
+	}
+	nextBucket := func(d time.Time) time.Time {
+		switch bucket {
+		case TrendBucketWeek:
+			return d.AddDate(0, 0, 7)
+		case TrendBucketMonth:
+			return d.AddDate(0, 1, 0)
+		}
+		return d.AddDate(0, 0, 1)
+	}
+
+	byBucket := make(map[string][]models.VibeCheck)
 	for _, check := range checks {
-		dateStr := check.CheckDate.Format("2006-01-02")
-		existingData[dateStr] = check
-	}
-
-	// Build result array with all dates, filling gaps
-	result := make([]map[string]interface{}, 0, days)
-	for i := 0; i < days; i++ {
-		date := startDate.AddDate(0, 0, i)
-		dateStr := date.Format("2006-01-02")
-
-		if check, exists := existingData[dateStr]; exists {
-			result = append(result, map[string]interface{}{
-				"date":       dateStr,
-				"vibe_score": check.VibeScore,
-				"aesthetic":  check.Aesthetic,
-				"emoji":      check.Emoji,
-			})
-		} else {
-			result = append(result, map[string]interface{}{
-				"date":       dateStr,
-				"vibe_score": 0,
-				"aesthetic":  "",
-				"emoji":      "",
-			})
+		key := bucketStart(check.CheckDate).Format("2006-01-02")
+		byBucket[key] = append(byBucket[key], check)
+	}
+
+	var result []dto.VibeTrendItem
+	for b := bucketStart(from); !b.After(to); b = nextBucket(b) {
+		key := b.Format("2006-01-02")
+		item := dto.VibeTrendItem{Date: key}
+		bucketChecks := byBucket[key]
+		if len(bucketChecks) == 0 {
+			result = append(result, item)
+			continue
+		}
+
+		total := 0
+		minScore, maxScore := bucketChecks[0].VibeScore, bucketChecks[0].VibeScore
+		aestheticCounts := make(map[string]int)
+		for _, check := range bucketChecks {
+			total += check.VibeScore
+			minScore = min(minScore, check.VibeScore)
+			maxScore = max(maxScore, check.VibeScore)
+			aestheticCounts[check.Aesthetic]++
+			if aestheticCounts[check.Aesthetic] > aestheticCounts[item.Aesthetic] {
+				item.Aesthetic, item.Emoji = check.Aesthetic, check.Emoji
+			}
 		}
+		avg := roundTo(float64(total)/float64(len(bucketChecks)), 1)
+		item.VibeScore, item.Min, item.Max, item.Count = &avg, &minScore, &maxScore, len(bucketChecks)
+		result = append(result, item)
 	}
 
 	return result, nil
 }
+
+// GetYearHeatmap returns one entry per calendar day of the year with that
+// day's score and aesthetic color, or null for days without a check.
+func (s *VibeService) GetYearHeatmap(userID uuid.UUID, year int) ([]dto.HeatmapDay, error) {
+	start := time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(1, 0, -1)
+
+	var checks []models.VibeCheck
+	if err := s.db.Select("check_date", "vibe_score", "aesthetic", "emoji", "color_primary").
+		Where("user_id = ? AND check_date >= ? AND check_date <= ?", userID, start, end).
+		Find(&checks).Error; err != nil {
+		return nil, err
+	}
+
+	byDate := make(map[string]models.VibeCheck, len(checks))
+	for _, check := range checks {
+		byDate[check.CheckDate.Format("2006-01-02")] = check
+	}
+
+	days := make([]dto.HeatmapDay, 0, daysBetween(start, end)+1)
+	for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+		day := dto.HeatmapDay{Date: d.Format("2006-01-02")}
+		if check, ok := byDate[day.Date]; ok {
+			score := check.VibeScore
+			day.VibeScore = &score
+			day.Aesthetic = check.Aesthetic
+			day.Emoji = check.Emoji
+			day.Color = check.ColorPrimary
+		}
+		days = append(days, day)
+	}
+
+	return days, nil
+}
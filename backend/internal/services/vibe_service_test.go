@@ -0,0 +1,117 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/dto"
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// newTestVibeService wires a VibeService the way main does, without an OpenAI
+// key so analysis takes the keyword fallback
+func newTestVibeService(db *gorm.DB) *VibeService {
+	moderation := NewModerationService(db)
+	settings := NewSettingsService(db)
+	streaks := NewStreakService(db)
+	friends := NewFriendService(db, moderation)
+	return NewVibeService(db, "",
+		NewQuizService(db),
+		streaks,
+		NewAchievementService(db),
+		NewForecastService(db),
+		NewAnomalyService(db, settings),
+		NewTermService(db),
+		settings,
+		NewDiscoverService(db, moderation, settings),
+		NewReferralService(db, streaks, friends),
+	)
+}
+
+func TestVibeCheckOnePerDayIndex(t *testing.T) {
+	// The unique index is what turns a concurrent duplicate check-in into
+	// ErrAlreadyCheckedIn, so make sure the model still declares it
+	s, err := schema.Parse(&models.VibeCheck{}, &sync.Map{}, schema.NamingStrategy{})
+	if err != nil {
+		t.Fatalf("parse VibeCheck: %v", err)
+	}
+
+	for _, idx := range s.ParseIndexes() {
+		if idx.Name != "idx_vibe_checks_user_date" {
+			continue
+		}
+		var columns []string
+		for _, f := range idx.Fields {
+			columns = append(columns, f.DBName)
+		}
+		if idx.Class != "UNIQUE" {
+			t.Errorf("class = %q, want UNIQUE", idx.Class)
+		}
+		if got := strings.Join(columns, ", "); got != "user_id, check_date" {
+			t.Errorf("columns = %s, want user_id, check_date", got)
+		}
+		// Soft-deleted checks mustn't block checking in again that day
+		if idx.Where != "deleted_at IS NULL" {
+			t.Errorf("where = %q, want deleted_at IS NULL", idx.Where)
+		}
+		return
+	}
+	t.Fatal("VibeCheck has no idx_vibe_checks_user_date index")
+}
+
+func TestCreateVibeCheckConcurrentSameDay(t *testing.T) {
+	db := testDB(t)
+	vibes := newTestVibeService(db)
+	user := createTestUser(t, db)
+
+	const attempts = 8
+	errs := make([]error, attempts)
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := range attempts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			_, errs[i] = vibes.CreateVibeCheck(user.ID, &dto.CreateVibeCheckRequest{MoodText: "calm and cozy with a coffee"})
+		}()
+	}
+	close(start) // Release them together so the check-ins race
+	wg.Wait()
+
+	created := 0
+	for i, err := range errs {
+		switch {
+		case err == nil:
+			created++
+		case !errors.Is(err, ErrAlreadyCheckedIn):
+			t.Errorf("attempt %d: got %v, want nil or ErrAlreadyCheckedIn", i, err)
+		}
+	}
+	if created != 1 {
+		t.Errorf("%d check-ins succeeded, want exactly 1", created)
+	}
+
+	var rows int64
+	if err := db.Model(&models.VibeCheck{}).Where("user_id = ?", user.ID).Count(&rows).Error; err != nil {
+		t.Fatalf("count checks: %v", err)
+	}
+	if rows != 1 {
+		t.Errorf("%d vibe_checks rows, want 1", rows)
+	}
+
+	var streak models.VibeStreak
+	if err := db.Where("user_id = ?", user.ID).First(&streak).Error; err != nil {
+		t.Fatalf("load streak: %v", err)
+	}
+	if streak.TotalChecks != 1 {
+		t.Errorf("TotalChecks = %d, want 1", streak.TotalChecks)
+	}
+	if streak.CurrentStreak != 1 {
+		t.Errorf("CurrentStreak = %d, want 1", streak.CurrentStreak)
+	}
+}
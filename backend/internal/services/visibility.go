@@ -0,0 +1,70 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm/clause"
+)
+
+var ErrInvalidVisibility = errors.New("visibility must be private, circles, friends or public")
+
+// checkReader is who is asking to read a check. Anonymous readers have a nil
+// UserID; ViaShareLink marks a reader holding a link the owner issued.
+type checkReader struct {
+	UserID       uuid.UUID
+	ViaShareLink bool
+}
+
+// readableBy is the single authorization rule for reading other users' check-ins.
+// Every read path (feed, circles, share links, compatibility, reactions and
+// comments) scopes its vibe_checks query with it, so a check can only be seen
+// by the audience its visibility allows:
+//   - the owner always
+//   - share link holders, unless the check is private
+//   - nobody else across a block
+//   - public: any signed-in user
+//   - friends: friends, plus members of a circle the owner belongs to
+//   - circles: members of a circle the owner belongs to, for checks made
+//     since the owner joined that circle
+//
+// alias is the name vibe_checks goes by in the query.
+func readableBy(alias string, reader checkReader) clause.Expression {
+	c := alias + "."
+	audiences := []string{c + "user_id = @reader"}
+	if reader.ViaShareLink {
+		audiences = append(audiences, c+"visibility <> 'private'")
+	}
+	if reader.UserID != uuid.Nil {
+		audiences = append(audiences, "(NOT EXISTS (SELECT 1 FROM blocks b"+
+			" WHERE (b.blocker_id = @reader AND b.blocked_id = "+c+"user_id) OR (b.blocker_id = "+c+"user_id AND b.blocked_id = @reader))"+
+			" AND ("+c+"visibility = 'public'"+
+			" OR ("+c+"visibility = 'friends' AND EXISTS (SELECT 1 FROM friendships f WHERE f.user_id = @reader AND f.friend_id = "+c+"user_id))"+
+			" OR ("+c+"visibility IN ('friends', 'circles') AND EXISTS (SELECT 1 FROM circle_members mine"+
+			" JOIN circle_members theirs ON theirs.circle_id = mine.circle_id"+
+			" JOIN circles ci ON ci.id = mine.circle_id AND ci.deleted_at IS NULL"+
+			" WHERE mine.user_id = @reader AND theirs.user_id = "+c+"user_id"+
+			" AND "+c+"check_date >= CAST(theirs.joined_at AS date)))))")
+	}
+
+	return clause.NamedExpr{
+		SQL:  c + "deleted_at IS NULL AND " + c + "user_id IS NOT NULL AND (" + strings.Join(audiences, " OR ") + ")",
+		Vars: []any{sql.Named("reader", reader.UserID)},
+	}
+}
+
+// moodTextReadable reports whether a reader allowed to see the check may
+// also see its words
+func moodTextReadable(check *models.VibeCheck, readerID uuid.UUID) bool {
+	return !check.HideMoodText || (check.UserID != nil && *check.UserID == readerID)
+}
+
+func validVisibility(visibility string) error {
+	if !models.CheckVisibilities[visibility] {
+		return ErrInvalidVisibility
+	}
+	return nil
+}
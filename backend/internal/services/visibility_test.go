@@ -0,0 +1,358 @@
+package services
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/dto"
+	"github.com/ahmetcoskunkizilkaya/vibecheck/backend/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// readableSQL renders a vibe_checks query scoped by readableBy, with the
+// reader's ID inlined. A dry-run session never connects, so this needs no
+// database.
+func readableSQL(t *testing.T, alias string, reader checkReader) string {
+	t.Helper()
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+	})
+	if err != nil {
+		t.Fatalf("open dry-run session: %v", err)
+	}
+	return db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Table("vibe_checks AS " + alias).Where(readableBy(alias, reader)).Find(&[]models.VibeCheck{})
+	})
+}
+
+func TestReadableBySQL(t *testing.T) {
+	reader := uuid.New()
+	r := "'" + reader.String() + "'"
+	signedIn := readableSQL(t, "c", checkReader{UserID: reader})
+	shared := readableSQL(t, "c", checkReader{ViaShareLink: true})
+
+	tests := []struct {
+		name     string
+		sql      string
+		contains []string
+		excludes []string
+	}{
+		{
+			name:     "deleted and device-only checks are never readable",
+			sql:      signedIn,
+			contains: []string{"c.deleted_at IS NULL AND c.user_id IS NOT NULL AND ("},
+		},
+		{
+			name:     "the owner reads every check of their own",
+			sql:      signedIn,
+			contains: []string{"(c.user_id = " + r + " OR "},
+			excludes: []string{"@reader"},
+		},
+		{
+			name: "a block either way hides every visibility",
+			sql:  signedIn,
+			contains: []string{"NOT EXISTS (SELECT 1 FROM blocks b WHERE (b.blocker_id = " + r + " AND b.blocked_id = c.user_id)" +
+				" OR (b.blocker_id = c.user_id AND b.blocked_id = " + r + ")) AND ("},
+		},
+		{
+			name:     "public: any signed-in user",
+			sql:      signedIn,
+			contains: []string{"c.visibility = 'public'"},
+		},
+		{
+			name:     "friends: the owner's friends",
+			sql:      signedIn,
+			contains: []string{"c.visibility = 'friends' AND EXISTS (SELECT 1 FROM friendships f WHERE f.user_id = " + r + " AND f.friend_id = c.user_id)"},
+		},
+		{
+			name: "friends and circles: members of a live shared circle, since the owner joined",
+			sql:  signedIn,
+			contains: []string{
+				"c.visibility IN ('friends', 'circles') AND EXISTS (SELECT 1 FROM circle_members mine",
+				"JOIN circles ci ON ci.id = mine.circle_id AND ci.deleted_at IS NULL",
+				"WHERE mine.user_id = " + r + " AND theirs.user_id = c.user_id",
+				"c.check_date >= CAST(theirs.joined_at AS date)",
+			},
+		},
+		{
+			name:     "private: nobody but the owner",
+			sql:      signedIn,
+			excludes: []string{"'private'"},
+		},
+		{
+			name:     "share links: anything but private, whoever holds them",
+			sql:      shared,
+			contains: []string{"c.visibility <> 'private'"},
+			excludes: []string{"blocks", "friendships", "circle_members", "'public'"},
+		},
+		{
+			name:     "the alias is used throughout",
+			sql:      readableSQL(t, "x", checkReader{UserID: reader}),
+			contains: []string{"x.visibility", "x.user_id", "x.check_date"},
+			excludes: []string{" c.", "(c."},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, want := range tt.contains {
+				if !strings.Contains(tt.sql, want) {
+					t.Errorf("missing %q in\n%s", want, tt.sql)
+				}
+			}
+			for _, unwanted := range tt.excludes {
+				if strings.Contains(tt.sql, unwanted) {
+					t.Errorf("unexpected %q in\n%s", unwanted, tt.sql)
+				}
+			}
+		})
+	}
+}
+
+func TestMoodTextReadable(t *testing.T) {
+	owner, other := uuid.New(), uuid.New()
+
+	tests := []struct {
+		name   string
+		hide   bool
+		reader uuid.UUID
+		want   bool
+	}{
+		{"shared, another user", false, other, true},
+		{"shared, share link", false, uuid.Nil, true},
+		{"hidden, another user", true, other, false},
+		{"hidden, share link", true, uuid.Nil, false},
+		{"hidden, the owner", true, owner, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := &models.VibeCheck{UserID: &owner, HideMoodText: tt.hide}
+			if got := moodTextReadable(check, tt.reader); got != tt.want {
+				t.Errorf("moodTextReadable = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// visibilityFixture is one check-in and the people around its owner: a friend,
+// a member of a circle the owner is in, a stranger, and a friend the owner has
+// blocked
+type visibilityFixture struct {
+	owner   uuid.UUID
+	circle  uuid.UUID
+	check   models.VibeCheck
+	readers map[string]uuid.UUID
+}
+
+func newVisibilityFixture(t *testing.T, db *gorm.DB, visibility string, hideMoodText bool) *visibilityFixture {
+	t.Helper()
+	f := &visibilityFixture{
+		owner: createTestUser(t, db).ID,
+		readers: map[string]uuid.UUID{
+			"friend":         createTestUser(t, db).ID,
+			"circle mate":    createTestUser(t, db).ID,
+			"stranger":       createTestUser(t, db).ID,
+			"blocked friend": createTestUser(t, db).ID,
+		},
+	}
+
+	for _, friend := range []uuid.UUID{f.readers["friend"], f.readers["blocked friend"]} {
+		mustCreate(t, db,
+			&models.Friendship{UserID: f.owner, FriendID: friend},
+			&models.Friendship{UserID: friend, FriendID: f.owner},
+		)
+	}
+	mustCreate(t, db, &models.Block{BlockerID: f.owner, BlockedID: f.readers["blocked friend"]})
+
+	circle := models.Circle{
+		OwnerID:     f.owner,
+		Name:        "Test circle",
+		InviteToken: strings.ReplaceAll(uuid.NewString(), "-", ""),
+		MaxMembers:  10,
+	}
+	mustCreate(t, db, &circle)
+	f.circle = circle.ID
+	joinedAt := time.Now().AddDate(0, 0, -1) // Before today's check
+	mustCreate(t, db,
+		&models.CircleMember{CircleID: circle.ID, UserID: f.owner, Role: models.CircleRoleOwner, JoinedAt: joinedAt},
+		&models.CircleMember{CircleID: circle.ID, UserID: f.readers["circle mate"], Role: models.CircleRoleMember, JoinedAt: joinedAt},
+	)
+
+	f.check = models.VibeCheck{
+		UserID:       &f.owner,
+		MoodText:     "quiet morning, good book",
+		Aesthetic:    "Cozy Era",
+		Emoji:        "☕",
+		VibeScore:    70,
+		Visibility:   visibility,
+		HideMoodText: hideMoodText,
+		CheckDate:    time.Now().Truncate(24 * time.Hour),
+	}
+	mustCreate(t, db, &f.check)
+	return f
+}
+
+func mustCreate(t *testing.T, db *gorm.DB, rows ...any) {
+	t.Helper()
+	for _, row := range rows {
+		if err := db.Create(row).Error; err != nil {
+			t.Fatalf("create %T: %v", row, err)
+		}
+	}
+}
+
+// feedItemFor finds the check among a feed page
+func feedItemFor(resp *dto.FeedResponse, checkID uuid.UUID) *dto.FeedItem {
+	for i := range resp.Data {
+		if resp.Data[i].CheckID == checkID {
+			return &resp.Data[i]
+		}
+	}
+	return nil
+}
+
+func TestCheckVisibility(t *testing.T) {
+	db := testDB(t)
+	moderation := NewModerationService(db)
+	feeds := NewFeedService(db)
+	circles := NewCircleService(db, moderation)
+	shares := NewShareService(db)
+	compatibility := NewCompatibilityService(db, NewFriendService(db, moderation), moderation)
+
+	tests := []struct {
+		visibility string
+		readers    []string // Who besides the owner may read the check
+	}{
+		{models.VisibilityPrivate, nil},
+		{models.VisibilityCircles, []string{"circle mate"}},
+		{models.VisibilityFriends, []string{"friend", "circle mate"}},
+		{models.VisibilityPublic, []string{"friend", "circle mate", "stranger"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.visibility, func(t *testing.T) {
+			f := newVisibilityFixture(t, db, tt.visibility, false)
+			day := f.check.CheckDate
+
+			readers := map[string]uuid.UUID{"owner": f.owner}
+			for name, id := range f.readers {
+				readers[name] = id
+			}
+			for name, reader := range readers {
+				want := name == "owner" || slices.Contains(tt.readers, name)
+
+				// Compatibility reads on behalf of any user
+				days, err := compatibility.windowChecks(f.owner, reader, day, day)
+				if err != nil {
+					t.Fatalf("%s: windowChecks: %v", name, err)
+				}
+				if got := len(days) == 1; got != want {
+					t.Errorf("%s: compatibility sees the check = %v, want %v", name, got, want)
+				}
+
+				// The friends feed only covers friends
+				if name == "friend" || name == "blocked friend" {
+					feed, err := feeds.GetFeed(reader, "", maxFeedLimit)
+					if err != nil {
+						t.Fatalf("%s: GetFeed: %v", name, err)
+					}
+					if got := feedItemFor(feed, f.check.ID) != nil; got != want {
+						t.Errorf("%s: feed shows the check = %v, want %v", name, got, want)
+					}
+				}
+
+				// And the circle feed its members
+				if name == "owner" || name == "circle mate" {
+					feed, err := circles.GetFeed(reader, f.circle, "", maxFeedLimit)
+					if err != nil {
+						t.Fatalf("%s: circle GetFeed: %v", name, err)
+					}
+					if got := feedItemFor(feed, f.check.ID) != nil; got != want {
+						t.Errorf("%s: circle feed shows the check = %v, want %v", name, got, want)
+					}
+				}
+			}
+
+			link, err := shares.CreateShareLink(f.owner, f.check.ID, &dto.CreateShareLinkRequest{ShowMoodText: true})
+			if tt.visibility == models.VisibilityPrivate {
+				if !errors.Is(err, ErrPrivateCheckShare) {
+					t.Errorf("CreateShareLink on a private check: got %v, want ErrPrivateCheckShare", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CreateShareLink: %v", err)
+			}
+			if _, _, err := shares.ResolveShareLink(link.Token, false); err != nil {
+				t.Errorf("ResolveShareLink: %v", err)
+			}
+
+			// Going private afterwards takes existing links down with it
+			if err := db.Model(&models.VibeCheck{}).Where("id = ?", f.check.ID).
+				Update("visibility", models.VisibilityPrivate).Error; err != nil {
+				t.Fatalf("make private: %v", err)
+			}
+			if _, _, err := shares.ResolveShareLink(link.Token, false); !errors.Is(err, ErrShareLinkNotFound) {
+				t.Errorf("ResolveShareLink after going private: got %v, want ErrShareLinkNotFound", err)
+			}
+		})
+	}
+}
+
+func TestHiddenMoodText(t *testing.T) {
+	db := testDB(t)
+	moderation := NewModerationService(db)
+	feeds := NewFeedService(db)
+	circles := NewCircleService(db, moderation)
+	shares := NewShareService(db)
+
+	f := newVisibilityFixture(t, db, models.VisibilityFriends, true)
+
+	feed, err := feeds.GetFeed(f.readers["friend"], "", maxFeedLimit)
+	if err != nil {
+		t.Fatalf("GetFeed: %v", err)
+	}
+	item := feedItemFor(feed, f.check.ID)
+	switch {
+	case item == nil:
+		t.Error("friend's feed is missing the check")
+	case item.MoodText != nil:
+		t.Errorf("friend's feed shows mood text %q", *item.MoodText)
+	}
+
+	// The circle feed shows the owner their own words but not the others
+	for name, reader := range map[string]uuid.UUID{"owner": f.owner, "circle mate": f.readers["circle mate"]} {
+		feed, err := circles.GetFeed(reader, f.circle, "", maxFeedLimit)
+		if err != nil {
+			t.Fatalf("%s: circle GetFeed: %v", name, err)
+		}
+		item := feedItemFor(feed, f.check.ID)
+		if item == nil {
+			t.Errorf("%s: circle feed is missing the check", name)
+			continue
+		}
+		if got, want := item.MoodText != nil, name == "owner"; got != want {
+			t.Errorf("%s: circle feed shows mood text = %v, want %v", name, got, want)
+		}
+	}
+
+	// A share link asking for the words doesn't override the check
+	link, err := shares.CreateShareLink(f.owner, f.check.ID, &dto.CreateShareLinkRequest{ShowMoodText: true})
+	if err != nil {
+		t.Fatalf("CreateShareLink: %v", err)
+	}
+	resolved, _, err := shares.ResolveShareLink(link.Token, false)
+	if err != nil {
+		t.Fatalf("ResolveShareLink: %v", err)
+	}
+	if resolved.ShowMoodText {
+		t.Error("share link shows mood text the check hides")
+	}
+}